@@ -61,6 +61,8 @@ func main() {
 
 	defer timer.ShutdownGlobalTimer() // Ensure clean shutdown
 	if err := cli.Execute(); err != nil {
+		// os.Exit skips deferred calls, so drain plugin events first
+		timer.ShutdownGlobalTimer()
 		// Check if this is a help request (which is not an error)
 		if err.Error() == "pflag: help requested" {
 			os.Exit(0)
@@ -1,7 +1,6 @@
 package cli
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/rsmacapinlac/pomodux/internal/config"
@@ -17,6 +16,7 @@ var breakCmd = &cobra.Command{
 }
 
 func init() {
+	addDetachFlag(breakCmd)
 	rootCmd.AddCommand(breakCmd)
 }
 
@@ -24,19 +24,7 @@ func runBreak(cmd *cobra.Command, args []string) error {
 	cfg, err := config.Load()
 	if err != nil {
 		cmd.PrintErrln("Warning: Failed to load configuration, using 5 minutes as default:", err)
-		duration := 5 * time.Minute
-		t := timer.GetGlobalTimer()
-		if t.GetStatus() == timer.StatusRunning {
-			cmd.PrintErrln("Timer already running.")
-			return fmt.Errorf("timer already running")
-		}
-		return t.StartPersistent(duration, timer.SessionTypeBreak)
+		return startSession(5*time.Minute, timer.SessionTypeBreak, timer.Annotation{}, false)
 	}
-	duration := cfg.Timer.DefaultBreakDuration
-	t := timer.GetGlobalTimer()
-	if t.GetStatus() == timer.StatusRunning {
-		cmd.PrintErrln("Timer already running.")
-		return fmt.Errorf("timer already running")
-	}
-	return t.StartPersistent(duration, timer.SessionTypeBreak)
+	return startSession(cfg.Timer.DefaultBreakDuration, timer.SessionTypeBreak, timer.Annotation{}, false)
 }
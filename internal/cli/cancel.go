@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/rsmacapinlac/pomodux/internal/timer"
+	"github.com/spf13/cobra"
+)
+
+var cancelCmd = &cobra.Command{
+	Use:   "cancel",
+	Short: "Discard the current session without recording it",
+	Long: `Cancel the current session. Unlike 'pomodux stop', the session is discarded
+and not recorded in history, and the Pomodoro cycle does not advance.`,
+	RunE: runCancel,
+}
+
+func init() {
+	addNameFlag(cancelCmd)
+	rootCmd.AddCommand(cancelCmd)
+}
+
+func runCancel(cmd *cobra.Command, args []string) error {
+	client, err := daemonClient()
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := client.Snapshot()
+	if err != nil {
+		return fmt.Errorf("failed to get timer status: %w", err)
+	}
+	if snapshot.Status != timer.StatusRunning && snapshot.Status != timer.StatusPaused {
+		cmd.PrintErrln("Cannot cancel timer: timer is not running (current status:", snapshot.Status, ")")
+		return fmt.Errorf("cannot cancel timer: timer is not running (current status: %v)", snapshot.Status)
+	}
+
+	if err := client.Cancel(); err != nil {
+		cmd.PrintErrln("Failed to cancel timer:", err)
+		return fmt.Errorf("failed to cancel timer: %w", err)
+	}
+
+	fmt.Println("Timer cancelled. Session discarded.")
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -65,7 +66,12 @@ var configSetCmd = &cobra.Command{
 	Long: `Set a configuration value using nested paths. Examples:
   pomodux config set timer.default_work_duration 25m
   pomodux config set timer.default_break_duration 5m
-  pomodux config set timer.default_long_break_duration 15m`,
+  pomodux config set timer.default_long_break_duration 15m
+  pomodux config set timer.long_break_interval 4
+  pomodux config set timer.auto_start_breaks true
+  pomodux config set goals.daily_pomodoros 8
+  pomodux config set goals.days mon,tue,wed,thu,fri
+  pomodux config set streaks.day_start_hour 4`,
 	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		key := args[0]
@@ -269,20 +275,107 @@ func setConfigValue(cfg *config.Config, key, value string) error {
 			return fmt.Errorf("invalid timer configuration key: %s", key)
 		}
 
-		duration, err := parseDuration(value)
-		if err != nil {
-			return fmt.Errorf("invalid duration: %w", err)
+		switch parts[1] {
+		case "default_work_duration", "default_break_duration", "default_long_break_duration":
+			duration, err := parseDuration(value)
+			if err != nil {
+				return fmt.Errorf("invalid duration: %w", err)
+			}
+			switch parts[1] {
+			case "default_work_duration":
+				cfg.Timer.DefaultWorkDuration = duration
+			case "default_break_duration":
+				cfg.Timer.DefaultBreakDuration = duration
+			case "default_long_break_duration":
+				cfg.Timer.DefaultLongBreakDuration = duration
+			}
+		case "long_break_interval":
+			interval, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid long break interval: %w", err)
+			}
+			cfg.Timer.LongBreakInterval = interval
+		case "auto_start_breaks":
+			enabled, err := parseBool(value)
+			if err != nil {
+				return fmt.Errorf("auto_start_breaks %w", err)
+			}
+			cfg.Timer.AutoStartBreaks = enabled
+		case "auto_start_work":
+			enabled, err := parseBool(value)
+			if err != nil {
+				return fmt.Errorf("auto_start_work %w", err)
+			}
+			cfg.Timer.AutoStartWork = enabled
+		case "overtime":
+			enabled, err := parseBool(value)
+			if err != nil {
+				return fmt.Errorf("overtime %w", err)
+			}
+			cfg.Timer.Overtime = enabled
+		default:
+			return fmt.Errorf("unknown timer setting: %s", parts[1])
+		}
+	case "goals":
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid goals configuration key: %s", key)
 		}
 
 		switch parts[1] {
-		case "default_work_duration":
-			cfg.Timer.DefaultWorkDuration = duration
-		case "default_break_duration":
-			cfg.Timer.DefaultBreakDuration = duration
-		case "default_long_break_duration":
-			cfg.Timer.DefaultLongBreakDuration = duration
+		case "daily_pomodoros", "weekly_pomodoros":
+			count, err := strconv.Atoi(value)
+			if err != nil || count < 0 {
+				return fmt.Errorf("invalid number of pomodoros: %s", value)
+			}
+			if parts[1] == "daily_pomodoros" {
+				cfg.Goals.DailyPomodoros = count
+			} else {
+				cfg.Goals.WeeklyPomodoros = count
+			}
+		case "daily_focus", "weekly_focus":
+			duration, err := parseDuration(value)
+			if err != nil || duration < 0 {
+				return fmt.Errorf("invalid duration: %s", value)
+			}
+			if parts[1] == "daily_focus" {
+				cfg.Goals.DailyFocus = duration
+			} else {
+				cfg.Goals.WeeklyFocus = duration
+			}
+		case "days":
+			var days []string
+			if value != "" {
+				days = strings.Split(value, ",")
+			}
+			if _, err := config.ParseWeekdays(days); err != nil {
+				return err
+			}
+			cfg.Goals.Days = days
 		default:
-			return fmt.Errorf("unknown timer setting: %s", parts[1])
+			return fmt.Errorf("unknown goals setting: %s", parts[1])
+		}
+	case "streaks":
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid streaks configuration key: %s", key)
+		}
+
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid number: %s", value)
+		}
+		switch parts[1] {
+		case "min_pomodoros":
+			if number < 1 {
+				return fmt.Errorf("min_pomodoros must be at least 1")
+			}
+			cfg.Streaks.MinPomodoros = number
+		case "day_start_hour":
+			if number < 0 || number > 23 {
+				return fmt.Errorf("day_start_hour must be between 0 and 23")
+			}
+			cfg.Streaks.DayStartHour = number
+		default:
+			return fmt.Errorf("unknown streaks setting: %s", parts[1])
 		}
 	case "logging":
 		if len(parts) != 3 {
@@ -368,6 +461,9 @@ func validateConfig(cfg *config.Config) error {
 	if cfg.Timer.DefaultLongBreakDuration > 2*time.Hour {
 		errors = append(errors, "default_long_break_duration should not exceed 2 hours")
 	}
+	if cfg.Timer.LongBreakInterval < 1 {
+		errors = append(errors, "long_break_interval must be at least 1")
+	}
 
 	if len(errors) > 0 {
 		return fmt.Errorf("configuration validation errors:\n  %s", strings.Join(errors, "\n  "))
@@ -383,6 +479,35 @@ func showConfigPretty(cfg *config.Config) {
 	fmt.Printf("  Default Work Duration:     %s\n", formatDuration(cfg.Timer.DefaultWorkDuration))
 	fmt.Printf("  Default Break Duration:    %s\n", formatDuration(cfg.Timer.DefaultBreakDuration))
 	fmt.Printf("  Default Long Break Duration: %s\n", formatDuration(cfg.Timer.DefaultLongBreakDuration))
+	fmt.Printf("  Long Break Interval:       every %d work sessions\n", cfg.Timer.LongBreakInterval)
+	fmt.Printf("  Auto Start Breaks:         %t\n", cfg.Timer.AutoStartBreaks)
+	fmt.Printf("  Auto Start Work:           %t\n", cfg.Timer.AutoStartWork)
+	fmt.Printf("  Overtime:                  %t\n", cfg.Timer.Overtime)
+	fmt.Printf("\nGoals:\n")
+	fmt.Printf("  Daily Pomodoros:           %d\n", cfg.Goals.DailyPomodoros)
+	fmt.Printf("  Daily Focus:               %s\n", formatDuration(cfg.Goals.DailyFocus))
+	fmt.Printf("  Weekly Pomodoros:          %d\n", cfg.Goals.WeeklyPomodoros)
+	fmt.Printf("  Weekly Focus:              %s\n", formatDuration(cfg.Goals.WeeklyFocus))
+	if len(cfg.Goals.Days) > 0 {
+		fmt.Printf("  Days:                      %s\n", strings.Join(cfg.Goals.Days, ", "))
+	}
+	fmt.Printf("\nStreaks:\n")
+	fmt.Printf("  Min Pomodoros per Day:     %d\n", cfg.Streaks.MinPomodoros)
+	fmt.Printf("  Day Starts At:             %02d:00\n", cfg.Streaks.DayStartHour)
+	if len(cfg.SessionTypes) > 0 {
+		fmt.Printf("\nSession Types:\n")
+		for _, name := range customSessionTypeNames(cfg) {
+			sessionType := cfg.SessionTypes[name]
+			line := fmt.Sprintf("  %-26s %s", name+":", formatDuration(sessionType.Duration))
+			if sessionType.Work {
+				line += ", counts as work"
+			}
+			if sessionType.Color != "" {
+				line += ", " + sessionType.Color
+			}
+			fmt.Println(line)
+		}
+	}
 	fmt.Printf("\nLogging Settings:\n")
 	fmt.Printf("  Level:      %s\n", cfg.Logging.Level)
 	fmt.Printf("  Format:     %s\n", cfg.Logging.Format)
@@ -394,114 +519,27 @@ func showConfigPretty(cfg *config.Config) {
 }
 
 func getProductivityTemplate() *config.Config {
-	return &config.Config{
-		Timer: struct {
-			DefaultWorkDuration      time.Duration `yaml:"default_work_duration"`
-			DefaultBreakDuration     time.Duration `yaml:"default_break_duration"`
-			DefaultLongBreakDuration time.Duration `yaml:"default_long_break_duration"`
-			AutoStartBreaks          bool          `yaml:"auto_start_breaks"`
-		}{
-			DefaultWorkDuration:      25 * time.Minute,
-			DefaultBreakDuration:     5 * time.Minute,
-			DefaultLongBreakDuration: 15 * time.Minute,
-			AutoStartBreaks:          false,
-		},
-		TUI: struct {
-			Theme       string            `yaml:"theme"`
-			KeyBindings map[string]string `yaml:"key_bindings"`
-		}{
-			Theme: "default",
-			KeyBindings: map[string]string{
-				"start":  "s",
-				"stop":   "q",
-				"pause":  "p",
-				"resume": "r",
-			},
-		},
-		Notifications: struct {
-			Enabled bool   `yaml:"enabled"`
-			Sound   bool   `yaml:"sound"`
-			Message string `yaml:"message"`
-		}{
-			Enabled: true,
-			Sound:   false,
-			Message: "Timer completed!",
-		},
-	}
+	cfg := config.DefaultConfig()
+	cfg.Timer.DefaultWorkDuration = 25 * time.Minute
+	cfg.Timer.DefaultBreakDuration = 5 * time.Minute
+	cfg.Timer.DefaultLongBreakDuration = 15 * time.Minute
+	return cfg
 }
 
 func getShortBreaksTemplate() *config.Config {
-	return &config.Config{
-		Timer: struct {
-			DefaultWorkDuration      time.Duration `yaml:"default_work_duration"`
-			DefaultBreakDuration     time.Duration `yaml:"default_break_duration"`
-			DefaultLongBreakDuration time.Duration `yaml:"default_long_break_duration"`
-			AutoStartBreaks          bool          `yaml:"auto_start_breaks"`
-		}{
-			DefaultWorkDuration:      45 * time.Minute,
-			DefaultBreakDuration:     3 * time.Minute,
-			DefaultLongBreakDuration: 10 * time.Minute,
-			AutoStartBreaks:          false,
-		},
-		TUI: struct {
-			Theme       string            `yaml:"theme"`
-			KeyBindings map[string]string `yaml:"key_bindings"`
-		}{
-			Theme: "default",
-			KeyBindings: map[string]string{
-				"start":  "s",
-				"stop":   "q",
-				"pause":  "p",
-				"resume": "r",
-			},
-		},
-		Notifications: struct {
-			Enabled bool   `yaml:"enabled"`
-			Sound   bool   `yaml:"sound"`
-			Message string `yaml:"message"`
-		}{
-			Enabled: true,
-			Sound:   false,
-			Message: "Timer completed!",
-		},
-	}
+	cfg := config.DefaultConfig()
+	cfg.Timer.DefaultWorkDuration = 45 * time.Minute
+	cfg.Timer.DefaultBreakDuration = 3 * time.Minute
+	cfg.Timer.DefaultLongBreakDuration = 10 * time.Minute
+	return cfg
 }
 
 func getLongBreaksTemplate() *config.Config {
-	return &config.Config{
-		Timer: struct {
-			DefaultWorkDuration      time.Duration `yaml:"default_work_duration"`
-			DefaultBreakDuration     time.Duration `yaml:"default_break_duration"`
-			DefaultLongBreakDuration time.Duration `yaml:"default_long_break_duration"`
-			AutoStartBreaks          bool          `yaml:"auto_start_breaks"`
-		}{
-			DefaultWorkDuration:      90 * time.Minute,
-			DefaultBreakDuration:     15 * time.Minute,
-			DefaultLongBreakDuration: 30 * time.Minute,
-			AutoStartBreaks:          false,
-		},
-		TUI: struct {
-			Theme       string            `yaml:"theme"`
-			KeyBindings map[string]string `yaml:"key_bindings"`
-		}{
-			Theme: "default",
-			KeyBindings: map[string]string{
-				"start":  "s",
-				"stop":   "q",
-				"pause":  "p",
-				"resume": "r",
-			},
-		},
-		Notifications: struct {
-			Enabled bool   `yaml:"enabled"`
-			Sound   bool   `yaml:"sound"`
-			Message string `yaml:"message"`
-		}{
-			Enabled: true,
-			Sound:   false,
-			Message: "Timer completed!",
-		},
-	}
+	cfg := config.DefaultConfig()
+	cfg.Timer.DefaultWorkDuration = 90 * time.Minute
+	cfg.Timer.DefaultBreakDuration = 15 * time.Minute
+	cfg.Timer.DefaultLongBreakDuration = 30 * time.Minute
+	return cfg
 }
 
 // parseDuration parses a duration string in various formats
@@ -509,6 +547,18 @@ func parseDuration(s string) (time.Duration, error) {
 	return time.ParseDuration(s)
 }
 
+// parseBool parses a true/false configuration value
+func parseBool(s string) (bool, error) {
+	switch s {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	default:
+		return false, fmt.Errorf("must be true or false")
+	}
+}
+
 // isSafeExecutable checks if the given executable is safe to run
 func isSafeExecutable(editor string) bool {
 	// List of safe editors
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/rsmacapinlac/pomodux/internal/daemon"
+	"github.com/rsmacapinlac/pomodux/internal/logger"
+	"github.com/rsmacapinlac/pomodux/internal/timer"
+	"github.com/spf13/cobra"
+)
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Run the background timer daemon",
+	Long: `Run the Pomodux timer daemon in the foreground.
+
+The daemon owns the timer and serves every other command over a Unix domain
+socket in $XDG_RUNTIME_DIR, so sessions keep running, complete, and notify
+plugins after the terminal that started them is closed. Other commands start
+the daemon automatically when it is not running.`,
+	Args: cobra.NoArgs,
+	RunE: runDaemon,
+}
+
+var daemonStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the background timer daemon",
+	Args:  cobra.NoArgs,
+	RunE:  runDaemonStop,
+}
+
+func init() {
+	daemonCmd.AddCommand(daemonStopCmd)
+	rootCmd.AddCommand(daemonCmd)
+}
+
+func runDaemon(cmd *cobra.Command, args []string) error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	cfg, err := loadConfig()
+	if err != nil {
+		logger.Warn("Failed to load configuration, using default cycle settings", map[string]interface{}{"error": err.Error()})
+		cfg = nil
+	}
+	// Named timers are configured the same way as the default timer
+	configure := func(t *timer.Timer) *timer.Timer {
+		if cfg == nil {
+			t.SetCycleConfig(timer.DefaultCycleConfig())
+			return t
+		}
+		t.SetCycleConfig(cycleConfigFrom(cfg))
+		t.SetSessionTypes(sessionTypesFrom(cfg))
+		t.SetGoals(goalsFrom(cfg))
+		t.SetStreaks(streakConfigFrom(cfg))
+		return t
+	}
+
+	server := daemon.NewServer(configure(timer.GetGlobalTimer()), daemon.SocketPath())
+	server.EnableNamedTimers(func(name string) *timer.Timer {
+		return configure(timer.NewNamedTimer(name))
+	}, timer.NamedTimerNames())
+	return server.ListenAndServe(ctx)
+}
+
+func runDaemonStop(cmd *cobra.Command, args []string) error {
+	client := daemon.NewClient(daemon.SocketPath())
+	if err := client.Ping(); err != nil {
+		fmt.Println("Daemon is not running.")
+		return nil
+	}
+
+	if err := client.Shutdown(); err != nil {
+		return fmt.Errorf("failed to stop daemon: %w", err)
+	}
+
+	fmt.Println("Daemon stopped.")
+	return nil
+}
+
+// timerName is the named timer given by --name; empty for the default timer
+var timerName string
+
+// addNameFlag adds the --name flag to a command that acts on a timer.
+func addNameFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&timerName, "name", "", "Act on the named timer instead of the default one")
+}
+
+// daemonClient connects to the timer daemon, starting it in the background
+// with the same configuration flags if it is not already running. The client
+// acts on the timer given by --name, or on the default timer.
+func daemonClient() (*daemon.Client, error) {
+	args := []string{"daemon"}
+	if cfgFile != "" {
+		args = append(args, "--config", cfgFile)
+	}
+	if noPlugins {
+		args = append(args, "--no-plugins")
+	}
+
+	client, err := daemon.Connect(daemon.SocketPath(), args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to timer daemon: %w", err)
+	}
+	return client.Named(timerName), nil
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rsmacapinlac/pomodux/internal/timer"
+	"github.com/spf13/cobra"
+)
+
+var extendCmd = &cobra.Command{
+	Use:   "extend [duration]",
+	Short: "Add time to or take time from the running session",
+	Long: `Change the planned duration of the running or paused session. A negative
+duration shortens it; put it after -- so it is not read as a flag:
+  pomodux extend 5m
+  pomodux extend -- -5m
+
+A session cannot be shortened to end before the time already elapsed. The
+extend key in the live display adds 5 minutes.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExtend,
+}
+
+func init() {
+	addNameFlag(extendCmd)
+	rootCmd.AddCommand(extendCmd)
+}
+
+func runExtend(cmd *cobra.Command, args []string) error {
+	by, err := time.ParseDuration(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid duration: %v", err)
+	}
+
+	client, err := daemonClient()
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := client.Snapshot()
+	if err != nil {
+		return fmt.Errorf("failed to get timer status: %w", err)
+	}
+	if snapshot.Status != timer.StatusRunning && snapshot.Status != timer.StatusPaused {
+		cmd.PrintErrln("Cannot extend session: timer is not running (current status:", snapshot.Status, ")")
+		return fmt.Errorf("cannot extend session: timer is not running (current status: %v)", snapshot.Status)
+	}
+
+	if err := client.Extend(by); err != nil {
+		cmd.PrintErrln("Failed to extend session:", err)
+		return fmt.Errorf("failed to extend session: %w", err)
+	}
+
+	extended, err := client.Snapshot()
+	if err != nil {
+		return fmt.Errorf("failed to get timer status: %w", err)
+	}
+	verb := "Extended"
+	if by < 0 {
+		verb = "Shortened"
+	}
+	fmt.Printf("%s %s session to %s, %s left.\n", verb, extended.SessionType, formatDuration(extended.Duration), formatDuration(extended.Remaining))
+	return nil
+}
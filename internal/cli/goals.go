@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rsmacapinlac/pomodux/internal/config"
+	"github.com/rsmacapinlac/pomodux/internal/logger"
+	"github.com/rsmacapinlac/pomodux/internal/theme"
+	"github.com/rsmacapinlac/pomodux/internal/timer"
+	"github.com/spf13/cobra"
+)
+
+var goalsCmd = &cobra.Command{
+	Use:   "goals",
+	Short: "Show progress towards daily and weekly goals",
+	Long: `Show how far today and this week are towards the goals set in the goals
+section of the configuration. Pomodoros are completed work sessions; focus is
+the time actually spent in work sessions. Weeks start on Monday.
+
+Example configuration:
+  goals:
+    daily_pomodoros: 8
+    weekly_focus: 20h
+    days: [mon, tue, wed, thu, fri]   # days daily goals apply on`,
+	Args: cobra.NoArgs,
+	RunE: runGoals,
+}
+
+var goalsJSON bool
+
+func init() {
+	goalsCmd.Flags().BoolVar(&goalsJSON, "json", false, "Output goal progress as JSON")
+	rootCmd.AddCommand(goalsCmd)
+}
+
+func runGoals(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	goals := goalsFrom(cfg)
+	if !goals.Active() {
+		fmt.Println("No goals set. Add them to the goals section of the configuration,")
+		fmt.Println("e.g. 'pomodux config set goals.daily_pomodoros 8'.")
+		return nil
+	}
+
+	progress, err := goalsProgress(goals, sessionTypesFrom(cfg), time.Now())
+	if err != nil {
+		return err
+	}
+
+	if goalsJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(goalInfos(progress))
+	}
+
+	th := loadTheme()
+	var period timer.GoalPeriod
+	for _, goal := range progress {
+		if goal.Period != period {
+			period = goal.Period
+			if period == timer.GoalDaily {
+				fmt.Printf("Today (%s)\n", goal.Start.Format("Mon 2 Jan"))
+			} else {
+				fmt.Printf("This week (from %s)\n", goal.Start.Format("Mon 2 Jan"))
+			}
+		}
+
+		role := string(timer.SessionTypeWork)
+		mark := ""
+		if goal.Reached() {
+			role = theme.RoleSuccess
+			mark = " " + strings.TrimSpace(th.Icon(theme.IconCompleted))
+		}
+		done := fmt.Sprintf("%d/%d", goal.Done, goal.Target)
+		if goal.Metric == timer.GoalFocus {
+			done = fmt.Sprintf("%s of %s", formatDuration(goal.Focus), formatDuration(goal.TargetFocus))
+		}
+		fmt.Printf("  %-10s %s %3.0f%%  %s%s\n", goal.Metric, th.ProgressBar(goal.Fraction(), 20, role), goal.Fraction()*100, done, mark)
+	}
+	return nil
+}
+
+// goalsFrom builds the timer's goals from the configuration. Invalid goal
+// days were already rejected when the configuration was loaded.
+func goalsFrom(cfg *config.Config) timer.Goals {
+	days, err := config.ParseWeekdays(cfg.Goals.Days)
+	if err != nil {
+		logger.Warn("Invalid goal days, applying daily goals every day", map[string]interface{}{"error": err.Error()})
+	}
+	return timer.Goals{
+		DailyPomodoros:  cfg.Goals.DailyPomodoros,
+		DailyFocus:      cfg.Goals.DailyFocus,
+		WeeklyPomodoros: cfg.Goals.WeeklyPomodoros,
+		WeeklyFocus:     cfg.Goals.WeeklyFocus,
+		Days:            days,
+	}
+}
+
+// goalsProgress measures progress towards goals from the session history.
+func goalsProgress(goals timer.Goals, types timer.SessionTypes, now time.Time) ([]timer.GoalProgress, error) {
+	historyManager, err := timer.NewHistoryManager()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create history manager: %w", err)
+	}
+	sessions, err := historyManager.GetAllSessions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load session history: %w", err)
+	}
+	return timer.GoalsProgress(goals, types, sessions, now), nil
+}
+
+// goalInfos describes goal progress for JSON output. Focus goals count seconds.
+func goalInfos(progress []timer.GoalProgress) []map[string]interface{} {
+	infos := make([]map[string]interface{}, 0, len(progress))
+	for _, goal := range progress {
+		info := map[string]interface{}{
+			"goal":     goal.Name(),
+			"period":   goal.Period,
+			"metric":   goal.Metric,
+			"start":    goal.Start.Format(time.RFC3339),
+			"target":   goal.Target,
+			"done":     goal.Done,
+			"progress": goal.Fraction(),
+			"reached":  goal.Reached(),
+		}
+		if goal.Metric == timer.GoalFocus {
+			info["target"] = int(goal.TargetFocus.Seconds())
+			info["done"] = int(goal.Focus.Seconds())
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
@@ -6,11 +6,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/rsmacapinlac/pomodux/internal/history"
 	"github.com/rsmacapinlac/pomodux/internal/logger"
+	"github.com/rsmacapinlac/pomodux/internal/theme"
 	"github.com/rsmacapinlac/pomodux/internal/timer"
 	"github.com/spf13/cobra"
 )
@@ -18,120 +21,261 @@ import (
 var historyCmd = &cobra.Command{
 	Use:   "history",
 	Short: "Show recent timer session(s)",
-	Long:  `Show recent completed timer sessions with filtering and export options.`,
-	RunE:  runHistory,
+	Long: `Show recorded timer sessions with filtering, sorting, paging and export options.
+
+Sessions can be limited to a time range by the day they started:
+  pomodux history --from 2025-01-01 --to 2025-01-31   # both days included
+  pomodux history --since 7d                          # also 2w, 12h, 90m
+  pomodux history --this-week                         # weeks start on Monday
+  pomodux history --last-month --stats
+
+Listings show --limit sessions per page (0 for all); use --page for more.
+Statistics and exports cover every matching session.
+
+Sessions can also be corrected by hand with the add, edit and rm subcommands,
+and the last correction undone with undo.`,
+	RunE: runHistory,
 }
 
 var (
-	historyJSON   bool
-	historyCSV    bool
-	historyLimit  int
-	historyType   string
-	historyDate   string
-	historyStats  bool
-	historyExport string
+	historyJSON    bool
+	historyCSV     bool
+	historyLimit   int
+	historyPage    int
+	historyType    string
+	historyDate    string
+	historyFrom    string
+	historyTo      string
+	historySince   string
+	historyRange   = make(map[string]*bool)
+	historySort    string
+	historyReverse bool
+	historyStats   bool
+	historyExport  string
+	historyTask    string
+	historyTags    []string
 )
 
 func init() {
 	historyCmd.Flags().BoolVar(&historyJSON, "json", false, "Output history as JSON")
 	historyCmd.Flags().BoolVar(&historyCSV, "csv", false, "Output history as CSV")
-	historyCmd.Flags().IntVar(&historyLimit, "limit", 10, "Number of sessions to show")
-	historyCmd.Flags().StringVar(&historyType, "type", "", "Filter by session type (work, break, long-break)")
+	historyCmd.Flags().IntVar(&historyLimit, "limit", 10, "Number of sessions per page (0 for all)")
+	historyCmd.Flags().IntVar(&historyPage, "page", 1, "Page of sessions to show")
+	historyCmd.Flags().StringVar(&historyType, "type", "", "Filter by session type (work, break, long-break or a type from session_types)")
 	historyCmd.Flags().StringVar(&historyDate, "date", "", "Filter by date (YYYY-MM-DD)")
+	historyCmd.Flags().StringVar(&historyFrom, "from", "", "Show sessions started from this date or time (YYYY-MM-DD or \"YYYY-MM-DD HH:MM\")")
+	historyCmd.Flags().StringVar(&historyTo, "to", "", "Show sessions started up to this date (included) or time")
+	historyCmd.Flags().StringVar(&historySince, "since", "", "Show sessions started within this long, e.g. 7d, 2w or 12h")
+	for _, name := range history.RangeNames {
+		historyRange[name] = historyCmd.Flags().Bool(name, false, "Show sessions started "+strings.ReplaceAll(name, "-", " "))
+	}
+	historyCmd.Flags().StringVar(&historySort, "sort", string(history.SortStart), "Sort by start (newest first), duration (longest first), type or task")
+	historyCmd.Flags().BoolVar(&historyReverse, "reverse", false, "Reverse the sort order")
+	historyCmd.Flags().StringVar(&historyTask, "task", "", "Filter by task (case-insensitive substring)")
+	historyCmd.Flags().StringSliceVar(&historyTags, "tag", nil, "Filter by tag (repeatable; sessions must have every tag)")
 	historyCmd.Flags().BoolVar(&historyStats, "stats", false, "Show session statistics")
 	historyCmd.Flags().StringVar(&historyExport, "export", "", "Export to file (specify path)")
 	rootCmd.AddCommand(historyCmd)
 }
 
 func runHistory(cmd *cobra.Command, args []string) error {
+	query, err := historyQuery(time.Now())
+	if err != nil {
+		return err
+	}
+
 	historyManager, err := timer.NewHistoryManager()
 	if err != nil {
 		return fmt.Errorf("failed to create history manager: %w", err)
 	}
 
-	sessions, err := historyManager.GetRecentSessions(100) // Get more sessions for filtering
+	// Statistics and exports cover every matching session
+	if historyStats || historyExport != "" {
+		query.Limit = 0
+	}
+	result, err := history.Run(historyManager, query)
 	if err != nil {
 		return fmt.Errorf("failed to get session history: %w", err)
 	}
 
-	// Apply filters
-	filteredSessions := filterSessions(sessions, historyType, historyDate)
-
-	// Limit results
-	if historyLimit > 0 && len(filteredSessions) > historyLimit {
-		filteredSessions = filteredSessions[:historyLimit]
-	}
-
 	// Handle export
 	if historyExport != "" {
-		return exportHistory(filteredSessions, historyExport, historyJSON, historyCSV)
+		return exportHistory(result.Sessions, historyExport, historyJSON, historyCSV)
 	}
 
-	// Show statistics if requested
+	// Show statistics if requested. Streaks run over the whole history, not
+	// just the matching sessions.
 	if historyStats {
-		showStatistics(filteredSessions)
+		all, err := historyManager.GetAllSessions()
+		if err != nil {
+			return fmt.Errorf("failed to get session history: %w", err)
+		}
+		showStatistics(result.Sessions, all)
 		return nil
 	}
 
 	// Handle output format
 	if historyJSON {
-		return outputHistoryJSON(filteredSessions)
+		return outputHistoryJSON(result.Sessions)
 	}
 
 	if historyCSV {
-		return outputHistoryCSV(filteredSessions)
+		return outputHistoryCSV(result.Sessions)
 	}
 
 	// Default text output
-	return outputHistoryText(filteredSessions)
+	if err := outputHistoryText(result.Sessions); err != nil {
+		return err
+	}
+	if result.Pages > 1 {
+		fmt.Printf("Page %d of %d (%d sessions).", result.Page, result.Pages, result.Total)
+		if result.Page < result.Pages {
+			fmt.Printf(" Use --page %d for more.", result.Page+1)
+		}
+		fmt.Println()
+	}
+	return nil
 }
 
-func filterSessions(sessions []timer.SessionRecord, sessionType, date string) []timer.SessionRecord {
-	var filtered []timer.SessionRecord
+// historyQuery builds the history query from the command line flags. Only
+// one way of giving the time range may be used at a time.
+func historyQuery(now time.Time) (history.Query, error) {
+	sortField, err := history.ParseSortField(historySort)
+	if err != nil {
+		return history.Query{}, err
+	}
+	if historyPage < 1 {
+		return history.Query{}, fmt.Errorf("page must be at least 1")
+	}
+	query := history.Query{
+		Type:    timer.SessionType(historyType),
+		Task:    historyTask,
+		Tags:    historyTags,
+		Sort:    sortField,
+		Reverse: historyReverse,
+		Page:    historyPage,
+		Limit:   historyLimit,
+	}
 
-	for _, session := range sessions {
-		// Filter by session type
-		if sessionType != "" && string(session.Type) != sessionType {
-			continue
+	var ranges []string
+	for _, name := range history.RangeNames {
+		if *historyRange[name] {
+			ranges = append(ranges, "--"+name)
 		}
+	}
+	if historyDate != "" {
+		ranges = append(ranges, "--date")
+	}
+	if historySince != "" {
+		ranges = append(ranges, "--since")
+	}
+	if historyFrom != "" || historyTo != "" {
+		ranges = append(ranges, "--from/--to")
+	}
+	if len(ranges) > 1 {
+		return history.Query{}, fmt.Errorf("give only one time range, not %s", strings.Join(ranges, " and "))
+	}
 
-		// Filter by date
-		if date != "" {
-			sessionDate := session.StartTime.Format("2006-01-02")
-			if sessionDate != date {
-				continue
-			}
+	for _, name := range history.RangeNames {
+		if *historyRange[name] {
+			query.From, query.To, err = history.NamedRange(name, now)
+			return query, err
 		}
-
-		filtered = append(filtered, session)
 	}
 
-	return filtered
+	if historyDate != "" {
+		day, err := time.ParseInLocation("2006-01-02", historyDate, now.Location())
+		if err != nil {
+			return history.Query{}, fmt.Errorf("invalid --date (use YYYY-MM-DD): %w", err)
+		}
+		query.From, query.To = day, day.AddDate(0, 0, 1)
+	}
+	if historySince != "" {
+		if query.From, err = history.ParseSince(historySince, now); err != nil {
+			return history.Query{}, err
+		}
+	}
+	if historyFrom != "" {
+		if query.From, _, err = history.ParseTime(historyFrom, now); err != nil {
+			return history.Query{}, fmt.Errorf("invalid --from: %w", err)
+		}
+	}
+	if historyTo != "" {
+		to, dateOnly, err := history.ParseTime(historyTo, now)
+		if err != nil {
+			return history.Query{}, fmt.Errorf("invalid --to: %w", err)
+		}
+		// A date includes the whole day
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+		query.To = to
+	}
+	if !query.From.IsZero() && !query.To.IsZero() && !query.From.Before(query.To) {
+		return history.Query{}, fmt.Errorf("--from must be before --to")
+	}
+	return query, nil
 }
 
-func showStatistics(sessions []timer.SessionRecord) {
+// showStatistics prints statistics for the matching sessions, with streaks
+// measured over all sessions.
+func showStatistics(sessions, all []timer.SessionRecord) {
 	if len(sessions) == 0 {
 		fmt.Println("No sessions found for statistics.")
 		return
 	}
 
-	var totalWorkTime, totalBreakTime time.Duration
-	var workSessions, breakSessions, longBreakSessions int
+	types := sessionTypes()
+	var totalWorkTime, totalBreakTime, totalOtherTime time.Duration
+	var workSessions, breakSessions, longBreakSessions, trackedSessions int
+	// countedWork is every session that counts as work, including user-defined types
+	var countedWork int
+	customSessions := make(map[timer.SessionType]int)
 	var completedSessions int
+	timeByTask := make(map[string]time.Duration)
+	timeByTag := make(map[string]time.Duration)
+
+	var pausedTime, overtime time.Duration
+	var interruptions, interruptedSessions int
 
 	for _, session := range sessions {
-		actualDuration := session.EndTime.Sub(session.StartTime)
+		actualDuration := session.ActiveDuration()
+
+		pausedTime += session.PausedTime()
+		overtime += session.Overtime
+		interruptions += len(session.Pauses)
+		if len(session.Pauses) > 0 {
+			interruptedSessions++
+		}
+
+		if session.Task != "" {
+			timeByTask[session.Task] += actualDuration
+		}
+		for _, tag := range session.Tags {
+			timeByTag[strings.ToLower(tag)] += actualDuration
+		}
 
-		switch session.Type {
-		case timer.SessionTypeWork:
+		// Stopwatch sessions are not pomodoros, whatever their type
+		switch {
+		case session.OpenEnded():
+			trackedSessions++
+		case session.Type == timer.SessionTypeWork:
 			workSessions++
-			totalWorkTime += actualDuration
-		case timer.SessionTypeBreak:
+		case session.Type == timer.SessionTypeBreak:
 			breakSessions++
-			totalBreakTime += actualDuration
-		case timer.SessionTypeLongBreak:
+		case session.Type == timer.SessionTypeLongBreak:
 			longBreakSessions++
+		default:
+			customSessions[session.Type]++
+		}
+		switch {
+		case types.CountsAsWork(session.Type):
+			countedWork++
+			totalWorkTime += actualDuration
+		case session.Type.IsBuiltin():
 			totalBreakTime += actualDuration
+		default:
+			totalOtherTime += actualDuration
 		}
 
 		if session.Completed {
@@ -150,36 +294,138 @@ func showStatistics(sessions []timer.SessionRecord) {
 	fmt.Printf("  Work Sessions:    %d (%.1f%%)\n", workSessions, float64(workSessions)/float64(totalSessions)*100)
 	fmt.Printf("  Break Sessions:   %d (%.1f%%)\n", breakSessions, float64(breakSessions)/float64(totalSessions)*100)
 	fmt.Printf("  Long Break Sessions: %d (%.1f%%)\n", longBreakSessions, float64(longBreakSessions)/float64(totalSessions)*100)
+	customTypes := make([]timer.SessionType, 0, len(customSessions))
+	for sessionType := range customSessions {
+		customTypes = append(customTypes, sessionType)
+	}
+	sort.Slice(customTypes, func(i, j int) bool { return customTypes[i] < customTypes[j] })
+	for _, sessionType := range customTypes {
+		count := customSessions[sessionType]
+		kind := ""
+		if types.CountsAsWork(sessionType) {
+			kind = ", counted as work"
+		}
+		fmt.Printf("  %s Sessions: %d (%.1f%%%s)\n", sessionType, count, float64(count)/float64(totalSessions)*100, kind)
+	}
+	if trackedSessions > 0 {
+		fmt.Printf("  Tracked Sessions: %d (%.1f%%)\n", trackedSessions, float64(trackedSessions)/float64(totalSessions)*100)
+	}
 	fmt.Printf("\nTime Totals:\n")
 	fmt.Printf("  Total Work Time:  %s\n", formatDuration(totalWorkTime))
 	fmt.Printf("  Total Break Time: %s\n", formatDuration(totalBreakTime))
-	fmt.Printf("  Total Time:       %s\n", formatDuration(totalWorkTime+totalBreakTime))
+	if totalOtherTime > 0 {
+		fmt.Printf("  Total Other Time: %s\n", formatDuration(totalOtherTime))
+	}
+	fmt.Printf("  Total Time:       %s\n", formatDuration(totalWorkTime+totalBreakTime+totalOtherTime))
 
-	if workSessions > 0 {
-		avgWorkTime := totalWorkTime / time.Duration(workSessions)
+	if overtime > 0 {
+		fmt.Printf("  Total Overtime:   %s\n", formatDuration(overtime))
+	}
+
+	if countedWork > 0 {
+		avgWorkTime := totalWorkTime / time.Duration(countedWork)
 		fmt.Printf("  Average Work Session: %s\n", formatDuration(avgWorkTime))
 	}
+
+	streakCfg := streakConfig()
+	streak := timer.Streaks(streakCfg, types, all, time.Now())
+	fmt.Printf("\nStreaks (days with %d+ %s):\n", streakCfg.MinPomodoros, "pomodoro"+plural(streakCfg.MinPomodoros))
+	fmt.Printf("  Current Streak:   %d day%s\n", streak.Current, plural(streak.Current))
+	fmt.Printf("  Longest Streak:   %d day%s\n", streak.Longest, plural(streak.Longest))
+	fmt.Printf("  Pomodoros Today:  %d\n", streak.Today)
+
+	fmt.Printf("\nInterruptions:\n")
+	fmt.Printf("  Pauses:           %d\n", interruptions)
+	fmt.Printf("  Paused Sessions:  %d (%.1f%%)\n", interruptedSessions, float64(interruptedSessions)/float64(totalSessions)*100)
+	fmt.Printf("  Total Paused Time: %s\n", formatDuration(pausedTime))
+
+	printBreakdown("Time by Task", timeByTask)
+	printBreakdown("Time by Tag", timeByTag)
+}
+
+// printBreakdown prints time totals per key, largest first.
+func printBreakdown(title string, totals map[string]time.Duration) {
+	if len(totals) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(totals))
+	for key := range totals {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if totals[keys[i]] != totals[keys[j]] {
+			return totals[keys[i]] > totals[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	fmt.Printf("\n%s:\n", title)
+	for _, key := range keys {
+		fmt.Printf("  %-30s %s\n", key, formatDuration(totals[key]))
+	}
 }
 
 func outputHistoryJSON(sessions []timer.SessionRecord) error {
+	type pauseOutput struct {
+		Start    time.Time `json:"start"`
+		End      time.Time `json:"end"`
+		Duration string    `json:"duration"`
+		Reason   string    `json:"reason,omitempty"`
+	}
 	type sessionOutput struct {
-		Type           string    `json:"type"`
-		Duration       string    `json:"duration"`
-		StartTime      time.Time `json:"start_time"`
-		EndTime        time.Time `json:"end_time"`
-		ActualDuration string    `json:"actual_duration"`
-		Completed      bool      `json:"completed"`
+		ID             string        `json:"id,omitempty"`
+		Type           string        `json:"type"`
+		Task           string        `json:"task,omitempty"`
+		Tags           []string      `json:"tags,omitempty"`
+		Duration       string        `json:"duration"`
+		StartTime      time.Time     `json:"start_time"`
+		EndTime        time.Time     `json:"end_time"`
+		ActualDuration string        `json:"actual_duration"`
+		Completed      bool          `json:"completed"`
+		Interruptions  int           `json:"interruptions"`
+		PausedTime     string        `json:"paused_time"`
+		Pauses         []pauseOutput `json:"pauses,omitempty"`
+		Overtime       string        `json:"overtime,omitempty"`
+		OpenEnded      bool          `json:"open_ended,omitempty"`
+		Notes          []timer.Note  `json:"notes,omitempty"`
+		TimerName      string        `json:"timer_name,omitempty"`
 	}
 
 	var output []sessionOutput
 	for _, session := range sessions {
+		var pauses []pauseOutput
+		for _, pause := range session.Pauses {
+			pauses = append(pauses, pauseOutput{
+				Start:    pause.Start,
+				End:      pause.End,
+				Duration: formatDuration(pause.Duration()),
+				Reason:   pause.Reason,
+			})
+		}
+
+		var overtime string
+		if session.Overtime > 0 {
+			overtime = formatDuration(session.Overtime)
+		}
+
 		output = append(output, sessionOutput{
+			ID:             session.ID,
 			Type:           string(session.Type),
+			Task:           session.Task,
+			Tags:           session.Tags,
 			Duration:       formatDuration(session.Duration),
 			StartTime:      session.StartTime,
 			EndTime:        session.EndTime,
-			ActualDuration: formatDuration(session.EndTime.Sub(session.StartTime)),
+			ActualDuration: formatDuration(session.ActiveDuration()),
 			Completed:      session.Completed,
+			Interruptions:  len(session.Pauses),
+			PausedTime:     formatDuration(session.PausedTime()),
+			Pauses:         pauses,
+			Overtime:       overtime,
+			OpenEnded:      session.OpenEnded(),
+			Notes:          session.Notes,
+			TimerName:      session.TimerName,
 		})
 	}
 
@@ -192,21 +438,45 @@ func outputHistoryCSV(sessions []timer.SessionRecord) error {
 	writer := csv.NewWriter(os.Stdout)
 	defer writer.Flush()
 
+	return writeHistoryCSV(writer, sessions)
+}
+
+// writeHistoryCSV writes sessions as CSV rows with a header.
+func writeHistoryCSV(writer *csv.Writer, sessions []timer.SessionRecord) error {
 	// Write header
-	header := []string{"Type", "Duration", "Start Time", "End Time", "Actual Duration", "Completed"}
+	header := []string{"Type", "Task", "Tags", "Duration", "Start Time", "End Time", "Actual Duration", "Completed", "Interruptions", "Paused Time", "Pause Reasons", "Overtime", "Notes", "Timer"}
 	if err := writer.Write(header); err != nil {
 		return err
 	}
 
 	// Write data
 	for _, session := range sessions {
+		var reasons []string
+		for _, pause := range session.Pauses {
+			if pause.Reason != "" {
+				reasons = append(reasons, pause.Reason)
+			}
+		}
+		var notes []string
+		for _, note := range session.Notes {
+			notes = append(notes, note.Text)
+		}
+
 		row := []string{
 			string(session.Type),
+			session.Task,
+			strings.Join(session.Tags, ";"),
 			formatDuration(session.Duration),
 			session.StartTime.Format("2006-01-02 15:04:05"),
 			session.EndTime.Format("2006-01-02 15:04:05"),
-			formatDuration(session.EndTime.Sub(session.StartTime)),
+			formatDuration(session.ActiveDuration()),
 			strconv.FormatBool(session.Completed),
+			strconv.Itoa(len(session.Pauses)),
+			formatDuration(session.PausedTime()),
+			strings.Join(reasons, ";"),
+			formatDuration(session.Overtime),
+			strings.Join(notes, ";"),
+			session.TimerName,
 		}
 		if err := writer.Write(row); err != nil {
 			return err
@@ -223,14 +493,45 @@ func outputHistoryText(sessions []timer.SessionRecord) error {
 		return nil
 	}
 
-	fmt.Printf("Recent completed sessions (%d total):\n\n", len(sessions))
+	th := loadTheme()
+	fmt.Printf("Recent completed sessions (%d shown):\n\n", len(sessions))
 	for i, session := range sessions {
-		fmt.Printf("%d. %s Session\n", i+1, session.Type)
-		fmt.Printf("   Duration: %s\n", session.Duration)
+		role := string(session.Type)
+		fmt.Printf("%d. %s%s Session\n", i+1, th.Icon(role), th.Paint(role, role))
+		if session.ID != "" {
+			fmt.Printf("   ID: %s\n", session.ID)
+		}
+		if session.TimerName != "" {
+			fmt.Printf("   Timer: %s\n", session.TimerName)
+		}
+		if session.Task != "" {
+			fmt.Printf("   Task: %s\n", session.Task)
+		}
+		if len(session.Tags) > 0 {
+			fmt.Printf("   Tags: %s\n", strings.Join(session.Tags, ", "))
+		}
+		if session.OpenEnded() {
+			fmt.Printf("   Duration: open-ended\n")
+		} else {
+			fmt.Printf("   Duration: %s\n", session.Duration)
+		}
 		fmt.Printf("   Start: %s\n", session.StartTime.Format("2006-01-02 15:04:05"))
 		fmt.Printf("   End: %s\n", session.EndTime.Format("2006-01-02 15:04:05"))
-		fmt.Printf("   Actual Duration: %s\n", session.EndTime.Sub(session.StartTime))
-		fmt.Printf("   Completed: %t\n\n", session.Completed)
+		fmt.Printf("   Actual Duration: %s\n", session.ActiveDuration())
+		if len(session.Pauses) > 0 {
+			fmt.Printf("   Paused: %d time(s), %s\n", len(session.Pauses), session.PausedTime())
+		}
+		if session.Overtime > 0 {
+			fmt.Printf("   Overtime: %s\n", session.Overtime)
+		}
+		for _, note := range session.Notes {
+			fmt.Printf("   Note (%s): %s\n", note.Time.Format("15:04"), note.Text)
+		}
+		completed := th.Icon(theme.IconIncomplete) + th.Paint(theme.RoleFailure, "false")
+		if session.Completed {
+			completed = th.Icon(theme.IconCompleted) + th.Paint(theme.RoleSuccess, "true")
+		}
+		fmt.Printf("   Completed: %s\n\n", completed)
 	}
 
 	return nil
@@ -258,28 +559,7 @@ func exportHistory(sessions []timer.SessionRecord, filepath string, jsonFormat,
 		writer := csv.NewWriter(file)
 		defer writer.Flush()
 
-		// Write header
-		header := []string{"Type", "Duration", "Start Time", "End Time", "Actual Duration", "Completed"}
-		if err := writer.Write(header); err != nil {
-			return err
-		}
-
-		// Write data
-		for _, session := range sessions {
-			row := []string{
-				string(session.Type),
-				formatDuration(session.Duration),
-				session.StartTime.Format("2006-01-02 15:04:05"),
-				session.EndTime.Format("2006-01-02 15:04:05"),
-				formatDuration(session.EndTime.Sub(session.StartTime)),
-				strconv.FormatBool(session.Completed),
-			}
-			if err := writer.Write(row); err != nil {
-				return err
-			}
-		}
-
-		return nil
+		return writeHistoryCSV(writer, sessions)
 	}
 
 	// Default to text format
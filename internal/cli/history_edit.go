@@ -0,0 +1,251 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rsmacapinlac/pomodux/internal/history"
+	"github.com/rsmacapinlac/pomodux/internal/timer"
+	"github.com/spf13/cobra"
+)
+
+var historyAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a session done away from the computer",
+	Long: `Add a session to history by hand. It must not overlap another session.
+
+Examples:
+  pomodux history add --start "2025-01-06 09:00" --duration 25m
+  pomodux history add --start 14:00 --duration 15m --type review --task "PR 42"`,
+	Args: cobra.NoArgs,
+	RunE: runHistoryAdd,
+}
+
+var historyEditCmd = &cobra.Command{
+	Use:   "edit <id>",
+	Short: "Correct a recorded session",
+	Long: `Change a recorded session, given its ID (or the start of it) as shown by
+'pomodux history'. Only the options given are changed. The session must not
+end up overlapping another session.
+
+Examples:
+  pomodux history edit 3f2a --end "2025-01-06 17:30"   # the timer ran all night
+  pomodux history edit 3f2a --duration 40m              # 40 minutes spent in it
+  pomodux history edit 3f2a --type review --task "PR 42"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runHistoryEdit,
+}
+
+var historyRmCmd = &cobra.Command{
+	Use:   "rm <id>",
+	Short: "Remove a recorded session",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runHistoryRm,
+}
+
+var historyUndoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Undo the last add, edit or rm",
+	Long: `Undo the last change made with history add, edit or rm. Sessions recorded
+since are kept. The history file as it was before the last change is also
+kept next to it with a .bak extension.`,
+	Args: cobra.NoArgs,
+	RunE: runHistoryUndo,
+}
+
+var (
+	editType      string
+	editStart     string
+	editEnd       string
+	editDuration  time.Duration
+	editPlanned   time.Duration
+	editTask      string
+	editTags      []string
+	editCompleted bool
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{historyAddCmd, historyEditCmd} {
+		cmd.Flags().StringVar(&editType, "type", "", "Session type (work, break, long-break or a type from session_types)")
+		cmd.Flags().StringVar(&editStart, "start", "", "When the session started (\"YYYY-MM-DD HH:MM\" or HH:MM today)")
+		cmd.Flags().DurationVar(&editDuration, "duration", 0, "How long was actually spent in the session")
+		cmd.Flags().DurationVar(&editPlanned, "planned", 0, "Planned duration of the session (default: --duration)")
+		cmd.Flags().StringVar(&editTask, "task", "", "What the session was spent on")
+		cmd.Flags().StringSliceVar(&editTags, "tag", nil, "Tag the session (repeatable; replaces existing tags)")
+		cmd.Flags().BoolVar(&editCompleted, "completed", true, "Whether the session was completed")
+	}
+	historyEditCmd.Flags().StringVar(&editEnd, "end", "", "When the session ended")
+
+	historyCmd.AddCommand(historyAddCmd, historyEditCmd, historyRmCmd, historyUndoCmd)
+}
+
+func runHistoryAdd(cmd *cobra.Command, args []string) error {
+	if editStart == "" || editDuration <= 0 {
+		return fmt.Errorf("give the session's --start and a positive --duration")
+	}
+	start, err := parseSessionTime(editStart)
+	if err != nil {
+		return fmt.Errorf("invalid --start: %w", err)
+	}
+
+	sessionType := timer.SessionTypeWork
+	if editType != "" {
+		if sessionType, _, err = lookupSessionType(editType); err != nil {
+			return err
+		}
+	}
+	planned := editDuration
+	if editPlanned > 0 {
+		planned = editPlanned
+	}
+
+	historyManager, err := timer.NewHistoryManager()
+	if err != nil {
+		return fmt.Errorf("failed to create history manager: %w", err)
+	}
+	session, err := historyManager.InsertSession(timer.SessionRecord{
+		Type:       sessionType,
+		Annotation: timer.NewAnnotation(editTask, editTags),
+		Duration:   planned,
+		StartTime:  start,
+		EndTime:    start.Add(editDuration),
+		Completed:  editCompleted,
+	})
+	if err != nil {
+		return historyEditError("add session", err)
+	}
+
+	fmt.Printf("Added %s, ID %s.\n", describeSession(session), session.ID)
+	return nil
+}
+
+func runHistoryEdit(cmd *cobra.Command, args []string) error {
+	historyManager, err := timer.NewHistoryManager()
+	if err != nil {
+		return fmt.Errorf("failed to create history manager: %w", err)
+	}
+	session, err := historyManager.FindSession(args[0])
+	if err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("type") {
+		if session.Type, _, err = lookupSessionType(editType); err != nil {
+			return err
+		}
+	}
+	if flags.Changed("planned") {
+		if editPlanned < 0 {
+			return fmt.Errorf("planned duration must not be negative")
+		}
+		session.Duration = editPlanned
+	}
+	if flags.Changed("task") || flags.Changed("tag") {
+		task, tags := session.Task, session.Tags
+		if flags.Changed("task") {
+			task = editTask
+		}
+		if flags.Changed("tag") {
+			tags = editTags
+		}
+		session.Annotation = timer.NewAnnotation(task, tags)
+	}
+	if flags.Changed("completed") {
+		session.Completed = editCompleted
+	}
+
+	// A new start moves the whole session unless the end is given too
+	start, end := session.StartTime, session.EndTime
+	if flags.Changed("start") {
+		if start, err = parseSessionTime(editStart); err != nil {
+			return fmt.Errorf("invalid --start: %w", err)
+		}
+		end = end.Add(start.Sub(session.StartTime))
+	}
+	switch {
+	case flags.Changed("end") && flags.Changed("duration"):
+		return fmt.Errorf("give either --end or --duration, not both")
+	case flags.Changed("end"):
+		if end, err = parseSessionTime(editEnd); err != nil {
+			return fmt.Errorf("invalid --end: %w", err)
+		}
+	case flags.Changed("duration"):
+		if editDuration <= 0 {
+			return fmt.Errorf("duration must be positive")
+		}
+		end = start.Add(editDuration + session.PausedTime())
+	}
+	session.Retime(start, end)
+
+	if err := historyManager.UpdateSession(session); err != nil {
+		return historyEditError("edit session", err)
+	}
+	fmt.Printf("Updated %s, ID %s.\n", describeSession(session), session.ID)
+	return nil
+}
+
+func runHistoryRm(cmd *cobra.Command, args []string) error {
+	historyManager, err := timer.NewHistoryManager()
+	if err != nil {
+		return fmt.Errorf("failed to create history manager: %w", err)
+	}
+	session, err := historyManager.RemoveSession(args[0])
+	if err != nil {
+		return historyEditError("remove session", err)
+	}
+	fmt.Printf("Removed %s, ID %s. Use 'pomodux history undo' to bring it back.\n", describeSession(session), session.ID)
+	return nil
+}
+
+func runHistoryUndo(cmd *cobra.Command, args []string) error {
+	historyManager, err := timer.NewHistoryManager()
+	if err != nil {
+		return fmt.Errorf("failed to create history manager: %w", err)
+	}
+	last, err := historyManager.UndoEdit()
+	if err != nil {
+		return err
+	}
+
+	switch last.Action {
+	case timer.HistoryEditAdd:
+		fmt.Printf("Undid adding %s.\n", describeSession(*last.After))
+	case timer.HistoryEditUpdate:
+		fmt.Printf("Undid editing %s; it is back to %s.\n", describeSession(*last.After), describeSession(*last.Before))
+	case timer.HistoryEditRemove:
+		fmt.Printf("Undid removing %s.\n", describeSession(*last.Before))
+	}
+	return nil
+}
+
+// parseSessionTime parses when a session started or ended. A time of day is
+// required; a date alone is not enough.
+func parseSessionTime(value string) (time.Time, error) {
+	t, dateOnly, err := history.ParseTime(value, time.Now())
+	if err != nil {
+		return time.Time{}, err
+	}
+	if dateOnly {
+		return time.Time{}, fmt.Errorf("give a time as well as the date, e.g. \"%s 09:00\"", t.Format("2006-01-02"))
+	}
+	return t, nil
+}
+
+// historyEditError explains why a change to history was refused.
+func historyEditError(action string, err error) error {
+	var overlap *timer.OverlapError
+	if errors.As(err, &overlap) {
+		return fmt.Errorf("cannot %s: it %w", action, err)
+	}
+	return fmt.Errorf("failed to %s: %w", action, err)
+}
+
+// describeSession summarizes a session in one line, such as
+// "work session on 2025-01-06 from 09:00 to 09:25 (25 minutes)".
+func describeSession(session timer.SessionRecord) string {
+	return fmt.Sprintf("%s session on %s from %s to %s (%s)", session.Type,
+		session.StartTime.Format("2006-01-02"), session.StartTime.Format("15:04"),
+		session.EndTime.Format("15:04"), formatDuration(session.ActiveDuration()))
+}
@@ -1,7 +1,6 @@
 package cli
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/rsmacapinlac/pomodux/internal/config"
@@ -17,6 +16,7 @@ var longBreakCmd = &cobra.Command{
 }
 
 func init() {
+	addDetachFlag(longBreakCmd)
 	rootCmd.AddCommand(longBreakCmd)
 }
 
@@ -24,19 +24,7 @@ func runLongBreak(cmd *cobra.Command, args []string) error {
 	cfg, err := config.Load()
 	if err != nil {
 		cmd.PrintErrln("Warning: Failed to load configuration, using 15 minutes as default:", err)
-		duration := 15 * time.Minute
-		t := timer.GetGlobalTimer()
-		if t.GetStatus() == timer.StatusRunning {
-			cmd.PrintErrln("Timer already running.")
-			return fmt.Errorf("timer already running")
-		}
-		return t.StartPersistent(duration, timer.SessionTypeLongBreak)
+		return startSession(15*time.Minute, timer.SessionTypeLongBreak, timer.Annotation{}, false)
 	}
-	duration := cfg.Timer.DefaultLongBreakDuration
-	t := timer.GetGlobalTimer()
-	if t.GetStatus() == timer.StatusRunning {
-		cmd.PrintErrln("Timer already running.")
-		return fmt.Errorf("timer already running")
-	}
-	return t.StartPersistent(duration, timer.SessionTypeLongBreak)
+	return startSession(cfg.Timer.DefaultLongBreakDuration, timer.SessionTypeLongBreak, timer.Annotation{}, false)
 }
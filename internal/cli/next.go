@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/rsmacapinlac/pomodux/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+var nextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Start the next session in the Pomodoro cycle",
+	Long: `Start whichever session comes next in the Pomodoro cycle: a work session after
+a break, a short break after a work session, or a long break once
+timer.long_break_interval work sessions have been completed.
+
+Durations come from the timer settings in the configuration file. With
+--overtime (or timer.overtime in the configuration) the session keeps counting
+past its planned end until it is stopped.`,
+	Args: cobra.NoArgs,
+	RunE: runNext,
+}
+
+func init() {
+	addAnnotationFlags(nextCmd)
+	addDetachFlag(nextCmd)
+	addOvertimeFlag(nextCmd)
+	rootCmd.AddCommand(nextCmd)
+}
+
+func runNext(cmd *cobra.Command, args []string) error {
+	client, err := daemonClient()
+	if err != nil {
+		return err
+	}
+
+	resumed, err := resolveInterrupted(client)
+	if err != nil {
+		return err
+	}
+	if resumed {
+		return runInteractive(client)
+	}
+
+	if err := client.StartNext(sessionAnnotation(), sessionOvertime); err != nil {
+		return fmt.Errorf("failed to start next session: %w", err)
+	}
+	logger.Info("Started next session in cycle")
+
+	return runInteractive(client)
+}
@@ -11,24 +11,37 @@ var pauseCmd = &cobra.Command{
 	Use:   "pause",
 	Short: "Pause the currently running timer",
 	Long: `Pause the currently running timer. The timer will stop counting down
-but retain its current progress. Use 'pomodux resume' to continue the timer.`,
+but retain its current progress. Use 'pomodux resume' to continue the timer.
+
+Every pause is recorded with the session, optionally with a reason:
+  pomodux pause --reason "call"`,
 	RunE: runPause,
 }
 
+var pauseReason string
+
 func init() {
+	pauseCmd.Flags().StringVar(&pauseReason, "reason", "", "Why the session is being interrupted")
+	addNameFlag(pauseCmd)
 	rootCmd.AddCommand(pauseCmd)
 }
 
 func runPause(cmd *cobra.Command, args []string) error {
-	t := timer.GetGlobalTimer()
+	client, err := daemonClient()
+	if err != nil {
+		return err
+	}
 
-	status := t.GetStatus()
-	if status != timer.StatusRunning {
-		cmd.PrintErrln("Cannot pause timer: timer is not running (current status:", status, ")")
-		return fmt.Errorf("cannot pause timer: timer is not running (current status: %v)", status)
+	snapshot, err := client.Snapshot()
+	if err != nil {
+		return fmt.Errorf("failed to get timer status: %w", err)
+	}
+	if snapshot.Status != timer.StatusRunning {
+		cmd.PrintErrln("Cannot pause timer: timer is not running (current status:", snapshot.Status, ")")
+		return fmt.Errorf("cannot pause timer: timer is not running (current status: %v)", snapshot.Status)
 	}
 
-	if err := t.Pause(); err != nil {
+	if err := client.PauseWithReason(pauseReason); err != nil {
 		cmd.PrintErrln("Failed to pause timer:", err)
 		return fmt.Errorf("failed to pause timer: %w", err)
 	}
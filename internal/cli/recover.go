@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rsmacapinlac/pomodux/internal/daemon"
+	"github.com/rsmacapinlac/pomodux/internal/logger"
+	"github.com/rsmacapinlac/pomodux/internal/timer"
+	"github.com/spf13/cobra"
+)
+
+var recoverCmd = &cobra.Command{
+	Use:   "recover [record|resume|discard]",
+	Short: "Resolve a session interrupted by a crash or reboot",
+	Long: `Resolve a session that was still running or paused when its timer process
+died, for example because the machine rebooted or the daemon was killed.
+
+  record   Record the session in history as interrupted (not completed)
+  resume   Continue the session from where it was interrupted
+  discard  Drop the session without recording it
+
+Without an argument you are asked which to do.`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: []string{string(timer.RecoveryRecord), string(timer.RecoveryResume), string(timer.RecoveryDiscard)},
+	RunE:      runRecover,
+}
+
+func init() {
+	rootCmd.AddCommand(recoverCmd)
+}
+
+func runRecover(cmd *cobra.Command, args []string) error {
+	client, err := daemonClient()
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := client.Snapshot()
+	if err != nil {
+		return fmt.Errorf("failed to get timer status: %w", err)
+	}
+	if snapshot.Status != timer.StatusInterrupted {
+		fmt.Println("No interrupted session to recover.")
+		return nil
+	}
+
+	var action timer.RecoveryAction
+	if len(args) == 1 {
+		action, err = timer.ParseRecoveryAction(args[0])
+	} else {
+		action, err = promptRecovery(snapshot, os.Stdin)
+	}
+	if err != nil {
+		return err
+	}
+
+	return applyRecovery(client, action)
+}
+
+// resolveInterrupted asks the user what to do with an interrupted session
+// before a new one is started. It reports whether the interrupted session
+// was resumed, in which case it is now running and no new session should start.
+func resolveInterrupted(client *daemon.Client) (bool, error) {
+	snapshot, err := client.Snapshot()
+	if err != nil {
+		return false, fmt.Errorf("failed to get timer status: %w", err)
+	}
+	if snapshot.Status != timer.StatusInterrupted {
+		return false, nil
+	}
+
+	if detached() {
+		return false, fmt.Errorf("an interrupted %s session was found; run 'pomodux recover record|resume|discard' first", snapshot.SessionType)
+	}
+
+	action, err := promptRecovery(snapshot, os.Stdin)
+	if err != nil {
+		return false, err
+	}
+	if err := client.Recover(action); err != nil {
+		return false, fmt.Errorf("failed to recover session: %w", err)
+	}
+	logger.Info("Recovered interrupted session", map[string]interface{}{"action": action})
+
+	return action == timer.RecoveryResume, nil
+}
+
+// applyRecovery resolves the interrupted session and, if it was resumed,
+// shows its live progress.
+func applyRecovery(client *daemon.Client, action timer.RecoveryAction) error {
+	if err := client.Recover(action); err != nil {
+		return fmt.Errorf("failed to recover session: %w", err)
+	}
+	logger.Info("Recovered interrupted session", map[string]interface{}{"action": action})
+
+	switch action {
+	case timer.RecoveryRecord:
+		fmt.Println("Interrupted session recorded in history.")
+	case timer.RecoveryDiscard:
+		fmt.Println("Interrupted session discarded.")
+	case timer.RecoveryResume:
+		return runInteractive(client)
+	}
+	return nil
+}
+
+// promptRecovery describes the interrupted session and reads the user's choice from in.
+func promptRecovery(snapshot timer.Snapshot, in io.Reader) (timer.RecoveryAction, error) {
+	fmt.Printf("Found an interrupted %s session started %s (%s of %s elapsed).\n",
+		snapshot.SessionType,
+		snapshot.StartTime.Format("2006-01-02 15:04:05"),
+		formatDuration(snapshot.Elapsed),
+		formatDuration(snapshot.Duration))
+
+	reader := bufio.NewReader(in)
+	for {
+		fmt.Print("Record it as interrupted, resume it, or discard it? [record/resume/discard]: ")
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return "", fmt.Errorf("no recovery action chosen; run 'pomodux recover record|resume|discard'")
+		}
+
+		action, err := timer.ParseRecoveryAction(strings.ToLower(strings.TrimSpace(line)))
+		if err == nil {
+			return action, nil
+		}
+		fmt.Println(err)
+	}
+}
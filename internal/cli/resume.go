@@ -15,19 +15,26 @@ var resumeCmd = &cobra.Command{
 }
 
 func init() {
+	addNameFlag(resumeCmd)
 	rootCmd.AddCommand(resumeCmd)
 }
 
 func runResume(cmd *cobra.Command, args []string) error {
-	t := timer.GetGlobalTimer()
+	client, err := daemonClient()
+	if err != nil {
+		return err
+	}
 
-	status := t.GetStatus()
-	if status != timer.StatusPaused {
-		cmd.PrintErrln("Cannot resume timer: timer is not paused (current status:", status, ")")
-		return fmt.Errorf("cannot resume timer: timer is not paused (current status: %v)", status)
+	snapshot, err := client.Snapshot()
+	if err != nil {
+		return fmt.Errorf("failed to get timer status: %w", err)
+	}
+	if snapshot.Status != timer.StatusPaused {
+		cmd.PrintErrln("Cannot resume timer: timer is not paused (current status:", snapshot.Status, ")")
+		return fmt.Errorf("cannot resume timer: timer is not paused (current status: %v)", snapshot.Status)
 	}
 
-	if err := t.Resume(); err != nil {
+	if err := client.Resume(); err != nil {
 		cmd.PrintErrln("Failed to resume timer:", err)
 		return fmt.Errorf("failed to resume timer: %w", err)
 	}
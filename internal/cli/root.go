@@ -1,12 +1,17 @@
 package cli
 
 import (
+	"fmt"
+
+	"github.com/rsmacapinlac/pomodux/internal/config"
+	"github.com/rsmacapinlac/pomodux/internal/timer"
 	"github.com/spf13/cobra"
 )
 
 var (
 	// Used for flags.
-	cfgFile string
+	cfgFile   string
+	noPlugins bool
 
 	rootCmd = &cobra.Command{
 		Use:   "pomodux",
@@ -21,6 +26,7 @@ Features:
   • Session tracking and statistics
   • Rich terminal user interface
   • Plugin system for extensibility`,
+		PersistentPreRunE: enablePlugins,
 	}
 )
 
@@ -42,12 +48,36 @@ func init() {
 	// will be global for your application.
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.config/pomodux/config.yaml)")
+	rootCmd.PersistentFlags().BoolVar(&noPlugins, "no-plugins", false, "disable loading of Lua plugins")
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 }
 
+// loadConfig loads the configuration from --config if it was given, or from
+// the default XDG location otherwise.
+func loadConfig() (*config.Config, error) {
+	if cfgFile != "" {
+		return config.LoadFromPath(cfgFile)
+	}
+	return config.Load()
+}
+
+// enablePlugins has the global timer load plugins from the configured
+// directory when it is first used, unless --no-plugins was given.
+func enablePlugins(cmd *cobra.Command, args []string) error {
+	if noPlugins {
+		return nil
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	timer.EnableGlobalPlugins(cfg.Plugins.Directory)
+	return nil
+}
+
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
 	// This function is called by cobra when the application starts
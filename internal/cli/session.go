@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rsmacapinlac/pomodux/internal/config"
+	"github.com/rsmacapinlac/pomodux/internal/daemon"
+	"github.com/rsmacapinlac/pomodux/internal/logger"
+	"github.com/rsmacapinlac/pomodux/internal/theme"
+	"github.com/rsmacapinlac/pomodux/internal/timer"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// Task and tags given to commands that start work sessions
+var (
+	sessionTask string
+	sessionTags []string
+)
+
+// addAnnotationFlags adds the --task and --tag flags to a command that starts sessions.
+func addAnnotationFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&sessionTask, "task", "", "What the session is spent on")
+	cmd.Flags().StringSliceVar(&sessionTags, "tag", nil, "Tag the session (repeatable)")
+}
+
+// sessionOvertime is set by --overtime to keep a session counting past its planned end
+var sessionOvertime bool
+
+// addOvertimeFlag adds the --overtime flag to a command that starts sessions.
+func addOvertimeFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&sessionOvertime, "overtime", false, "Keep counting past the planned end until stopped")
+}
+
+// sessionDetach is set by --detach to start a session without the live display
+var sessionDetach bool
+
+// addDetachFlag adds the --detach flag to a command that starts sessions.
+func addDetachFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&sessionDetach, "detach", "d", false, "Start the session in the background and return immediately")
+}
+
+// detached reports whether sessions should be left to the daemon without the
+// live display: with --detach, or when standard input is not a terminal, as
+// when started from a window manager keybinding, cron or a script.
+func detached() bool {
+	return sessionDetach || !term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// sessionAnnotation returns the annotation given by the --task and --tag flags.
+func sessionAnnotation() timer.Annotation {
+	return timer.NewAnnotation(sessionTask, sessionTags)
+}
+
+// startSession starts a session in the daemon and shows its live progress
+// until it completes or is stopped.
+func startSession(duration time.Duration, sessionType timer.SessionType, annotation timer.Annotation, overtime bool) error {
+	client, err := daemonClient()
+	if err != nil {
+		return err
+	}
+
+	resumed, err := resolveInterrupted(client)
+	if err != nil {
+		return err
+	}
+	if resumed {
+		return runInteractive(client)
+	}
+
+	if err := client.Start(duration, sessionType, annotation, overtime); err != nil {
+		return fmt.Errorf("failed to start timer: %w", err)
+	}
+	logger.Info("Timer started", map[string]interface{}{"duration": duration, "session_type": sessionType, "task": annotation.Task, "tags": annotation.Tags, "overtime": overtime})
+
+	return runInteractive(client)
+}
+
+// runInteractive shows the interactive display for the daemon's session,
+// using the configured key bindings and theme. When detached it only reports
+// the session, which the daemon completes and records on its own.
+func runInteractive(client *daemon.Client) error {
+	if detached() {
+		return reportDetached(client)
+	}
+	return timer.RunInteractive(client, interactiveKeys(), loadTheme())
+}
+
+// reportDetached prints a one-line summary of the session left running in the daemon.
+func reportDetached(client *daemon.Client) error {
+	snapshot, err := client.Snapshot()
+	if err != nil {
+		return fmt.Errorf("failed to get timer status: %w", err)
+	}
+
+	summary := fmt.Sprintf("Started %s session", snapshot.SessionType)
+	switch {
+	case snapshot.Stopwatch:
+		summary = fmt.Sprintf("Started %s stopwatch", snapshot.SessionType)
+	case !snapshot.EndTime.IsZero():
+		summary += fmt.Sprintf(" for %v, ending at %s", snapshot.Duration, snapshot.EndTime.Format("15:04"))
+	}
+	if snapshot.Task != "" {
+		summary += fmt.Sprintf(" (%s)", snapshot.Task)
+	}
+	fmt.Println(summary + ".")
+	return nil
+}
+
+// loadTheme returns the configured theme, falling back to the default theme
+// if it cannot be loaded.
+func loadTheme() *theme.Theme {
+	cfg, cfgErr := loadConfig()
+	name := ""
+	if cfgErr == nil {
+		name = cfg.TUI.Theme
+	}
+	th, err := theme.Load(name)
+	if err != nil {
+		logger.Warn("Failed to load theme, using default", map[string]interface{}{"theme": name, "error": err.Error()})
+		th = theme.Default()
+		if theme.ColorEnabled() {
+			_ = th.EnableColor()
+		}
+	}
+	if cfgErr != nil {
+		return th
+	}
+
+	// User-defined session types bring their own colors
+	for sessionType, definition := range cfg.SessionTypes {
+		if definition.Color == "" {
+			continue
+		}
+		if err := th.SetColor(sessionType, definition.Color); err != nil {
+			logger.Warn("Invalid session type color", map[string]interface{}{"session_type": sessionType, "error": err.Error()})
+		}
+	}
+	return th
+}
+
+// interactiveKeys returns the key bindings for the interactive timer from the
+// configuration, or the defaults if it cannot be loaded.
+func interactiveKeys() timer.KeyMap {
+	cfg, err := loadConfig()
+	if err != nil {
+		logger.Warn("Failed to load key bindings, using defaults", map[string]interface{}{"error": err.Error()})
+		return timer.NewKeyMap(config.DefaultKeyBindings())
+	}
+	return timer.NewKeyMap(cfg.TUI.KeyBindings)
+}
+
+// cycleConfigFrom builds the timer's Pomodoro cycle settings from the configuration.
+func cycleConfigFrom(cfg *config.Config) timer.CycleConfig {
+	return timer.CycleConfig{
+		WorkDuration:      cfg.Timer.DefaultWorkDuration,
+		BreakDuration:     cfg.Timer.DefaultBreakDuration,
+		LongBreakDuration: cfg.Timer.DefaultLongBreakDuration,
+		LongBreakInterval: cfg.Timer.LongBreakInterval,
+		AutoStartBreaks:   cfg.Timer.AutoStartBreaks,
+		AutoStartWork:     cfg.Timer.AutoStartWork,
+		Overtime:          cfg.Timer.Overtime,
+	}
+}
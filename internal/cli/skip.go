@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/rsmacapinlac/pomodux/internal/timer"
+	"github.com/spf13/cobra"
+)
+
+var skipCmd = &cobra.Command{
+	Use:   "skip",
+	Short: "Skip to the next session in the Pomodoro cycle",
+	Long: `End the current session now and start the next session in the Pomodoro cycle.
+The skipped session is recorded in history as skipped and not completed, but
+still counts as a step in the cycle.`,
+	RunE: runSkip,
+}
+
+func init() {
+	rootCmd.AddCommand(skipCmd)
+}
+
+func runSkip(cmd *cobra.Command, args []string) error {
+	client, err := daemonClient()
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := client.Snapshot()
+	if err != nil {
+		return fmt.Errorf("failed to get timer status: %w", err)
+	}
+	if snapshot.Status != timer.StatusRunning && snapshot.Status != timer.StatusPaused {
+		cmd.PrintErrln("Cannot skip session: timer is not running (current status:", snapshot.Status, ")")
+		return fmt.Errorf("cannot skip session: timer is not running (current status: %v)", snapshot.Status)
+	}
+
+	if err := client.Skip(); err != nil {
+		cmd.PrintErrln("Failed to skip session:", err)
+		return fmt.Errorf("failed to skip session: %w", err)
+	}
+
+	next, err := client.Snapshot()
+	if err != nil {
+		return fmt.Errorf("failed to get timer status: %w", err)
+	}
+
+	fmt.Printf("Skipped %s session. Started %s session for %s.\n", snapshot.SessionType, next.SessionType, formatDuration(next.Duration))
+	return nil
+}
@@ -2,9 +2,12 @@ package cli
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/rsmacapinlac/pomodux/internal/config"
+	"github.com/rsmacapinlac/pomodux/internal/logger"
 	"github.com/rsmacapinlac/pomodux/internal/timer"
 	"github.com/spf13/cobra"
 )
@@ -18,18 +21,46 @@ Examples:
   pomodux start 25m          # Start a 25-minute work session
   pomodux start 1h30m        # Start a 1 hour 30 minute session
   pomodux start 45s          # Start a 45-second session
+  pomodux start 25m --task "Review PR 42" --tag backend --tag review
+  pomodux start 25m --overtime  # Keep counting past 25 minutes until stopped
+  pomodux start --until 14:30   # Work until 14:30 (tomorrow if 14:30 has passed)
+  pomodux start --until "tomorrow 09:00"
+  pomodux start --type review   # Start a session of a type from session_types
   
-If no duration is specified, uses the default work duration from config.`,
+If no duration is specified, uses the default work duration from config, or
+the duration of the session type given with --type.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		var duration time.Duration
 		var err error
 
-		if len(args) > 0 {
+		sessionType := timer.SessionTypeWork
+		var typeDuration time.Duration
+		if startType != "" {
+			sessionType, typeDuration, err = lookupSessionType(startType)
+			if err != nil {
+				return err
+			}
+		}
+
+		if startUntil != "" {
+			if len(args) > 0 {
+				return fmt.Errorf("give either a duration or --until, not both")
+			}
+			now := time.Now()
+			target, err := timer.ParseUntil(startUntil, now)
+			if err != nil {
+				return fmt.Errorf("invalid --until: %w", err)
+			}
+			duration = target.Sub(now).Round(time.Second)
+			fmt.Printf("Working until %s\n", target.Format("Mon 15:04"))
+		} else if len(args) > 0 {
 			duration, err = time.ParseDuration(args[0])
 			if err != nil {
 				return fmt.Errorf("invalid duration: %v", err)
 			}
+		} else if typeDuration > 0 {
+			duration = typeDuration
 		} else {
 			// Use default work duration from config
 			cfg, err := config.Load()
@@ -45,19 +76,80 @@ If no duration is specified, uses the default work duration from config.`,
 			return fmt.Errorf("duration must be positive")
 		}
 
-		// Get the global timer
-		t := timer.GetGlobalTimer()
+		// Start the session in the daemon (this will block until completion)
+		return startSession(duration, sessionType, sessionAnnotation(), sessionOvertime)
+	},
+}
 
-		// Check if timer is already running
-		if t.GetStatus() == timer.StatusRunning {
-			return fmt.Errorf("timer already running")
-		}
+var (
+	// startUntil is the wall-clock time given by --until
+	startUntil string
+	// startType is the session type given by --type
+	startType string
+)
 
-		// Start the persistent timer (this will block until completion)
-		return t.StartPersistent(duration, timer.SessionTypeWork)
-	},
+// lookupSessionType returns the session type with the given name and its
+// configured duration. Built-in types take their durations from the timer
+// settings.
+func lookupSessionType(name string) (timer.SessionType, time.Duration, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	sessionType := timer.SessionType(name)
+	if sessionType.IsBuiltin() {
+		return sessionType, cycleConfigFrom(cfg).DurationFor(sessionType), nil
+	}
+	definition, ok := cfg.SessionTypes[name]
+	if !ok {
+		known := append([]string{string(timer.SessionTypeWork), string(timer.SessionTypeBreak), string(timer.SessionTypeLongBreak)}, customSessionTypeNames(cfg)...)
+		return "", 0, fmt.Errorf("unknown session type %q (known types: %s)", name, strings.Join(known, ", "))
+	}
+	return sessionType, definition.Duration, nil
+}
+
+// sessionTypesFrom builds the user-defined session types from the configuration.
+func sessionTypesFrom(cfg *config.Config) timer.SessionTypes {
+	defs := make([]timer.SessionTypeDef, 0, len(cfg.SessionTypes))
+	for name, sessionType := range cfg.SessionTypes {
+		defs = append(defs, timer.SessionTypeDef{
+			Name:     timer.SessionType(name),
+			Duration: sessionType.Duration,
+			Work:     sessionType.Work,
+			Message:  sessionType.Message,
+		})
+	}
+	return timer.NewSessionTypes(defs)
+}
+
+// sessionTypes reads the user-defined session types, falling back to none
+// when the configuration cannot be loaded.
+func sessionTypes() timer.SessionTypes {
+	cfg, err := loadConfig()
+	if err != nil {
+		logger.Warn("Failed to load configuration, using no session types", map[string]interface{}{"error": err.Error()})
+		return nil
+	}
+	return sessionTypesFrom(cfg)
+}
+
+// customSessionTypeNames lists the user-defined session types by name.
+func customSessionTypeNames(cfg *config.Config) []string {
+	names := make([]string, 0, len(cfg.SessionTypes))
+	for name := range cfg.SessionTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
 func init() {
+	startCmd.Flags().StringVar(&startUntil, "until", "", "Work until a clock time, e.g. 14:30 or \"tomorrow 09:00\"")
+	startCmd.Flags().StringVar(&startType, "type", "", "Session type: work, break, long-break or one defined in session_types")
+	addAnnotationFlags(startCmd)
+	addDetachFlag(startCmd)
+	addOvertimeFlag(startCmd)
+	addNameFlag(startCmd)
 	rootCmd.AddCommand(startCmd)
 }
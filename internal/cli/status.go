@@ -4,9 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/rsmacapinlac/pomodux/internal/daemon"
 	"github.com/rsmacapinlac/pomodux/internal/logger"
+	"github.com/rsmacapinlac/pomodux/internal/theme"
 	"github.com/rsmacapinlac/pomodux/internal/timer"
 	"github.com/spf13/cobra"
 )
@@ -18,53 +21,189 @@ var statusCmd = &cobra.Command{
 	RunE:  runStatus,
 }
 
-var statusJSON bool
+var (
+	statusJSON bool
+	statusAll  bool
+)
 
 func init() {
 	statusCmd.Flags().BoolVar(&statusJSON, "json", false, "Output status as JSON")
+	statusCmd.Flags().BoolVar(&statusAll, "all", false, "Show the default timer and every named timer")
+	addNameFlag(statusCmd)
 	rootCmd.AddCommand(statusCmd)
 }
 
 func runStatus(cmd *cobra.Command, args []string) error {
-	t := timer.GetGlobalTimer()
+	client, err := daemonClient()
+	if err != nil {
+		return err
+	}
+
+	if statusAll {
+		return showAllTimers(client)
+	}
+
+	snapshot, err := client.Snapshot()
+	if err != nil {
+		return fmt.Errorf("failed to get timer status: %w", err)
+	}
+
+	statusInfo := statusInfoFor(snapshot)
+	if statusJSON {
+		if snapshot.TimerName == "" {
+			statusInfo["goals"] = statusGoals()
+			statusInfo["streak"] = statusStreak()
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(statusInfo)
+	}
+
+	logger.Debug("Status info", statusInfo)
+	printStatus(snapshot)
+	return nil
+}
+
+// showAllTimers lists the default timer and every named timer.
+func showAllTimers(client *daemon.Client) error {
+	snapshots, err := client.List()
+	if err != nil {
+		return fmt.Errorf("failed to list timers: %w", err)
+	}
+
+	if statusJSON {
+		infos := make([]map[string]interface{}, 0, len(snapshots))
+		for _, snapshot := range snapshots {
+			infos = append(infos, statusInfoFor(snapshot))
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(infos)
+	}
 
-	status := t.GetStatus()
-	progress := t.GetProgress()
-	sessionType := t.GetSessionType()
-	startTime := t.GetStartTime()
-	duration := t.GetDuration()
-	elapsed := t.GetElapsed()
+	th := loadTheme()
+	for _, snapshot := range snapshots {
+		name := snapshot.TimerName
+		if name == "" {
+			name = timer.DefaultTimerName
+		}
+		role := string(snapshot.SessionType)
 
-	remaining := duration - elapsed
-	if remaining < 0 {
-		remaining = 0
+		line := fmt.Sprintf("%-12s %-11s", name, snapshot.Status)
+		switch {
+		case snapshot.Status != timer.StatusRunning && snapshot.Status != timer.StatusPaused:
+		case snapshot.Stopwatch:
+			line += fmt.Sprintf(" %s%s %s elapsed", th.Icon(role), th.Paint(role, fmt.Sprintf("%-10s", role)), formatDuration(snapshot.Elapsed))
+		default:
+			line += fmt.Sprintf(" %s%s %s left", th.Icon(role), th.Paint(role, fmt.Sprintf("%-10s", role)), formatDuration(snapshot.Remaining))
+		}
+		if snapshot.Task != "" {
+			line += "  " + snapshot.Task
+		}
+		fmt.Println(line)
 	}
+	return nil
+}
+
+// statusGoals describes progress towards the configured goals, or is empty
+// when no goals are set or they cannot be measured.
+func statusGoals() []map[string]interface{} {
+	cfg, err := loadConfig()
+	if err != nil {
+		return []map[string]interface{}{}
+	}
+	progress, err := goalsProgress(goalsFrom(cfg), sessionTypesFrom(cfg), time.Now())
+	if err != nil {
+		logger.Warn("Failed to measure goal progress", map[string]interface{}{"error": err.Error()})
+	}
+	return goalInfos(progress)
+}
+
+// statusInfoFor describes a timer for JSON output.
+func statusInfoFor(snapshot timer.Snapshot) map[string]interface{} {
+	status := snapshot.Status
+	progress := snapshot.Progress
+	sessionType := snapshot.SessionType
+	startTime := snapshot.StartTime
+	duration := snapshot.Duration
+	elapsed := snapshot.Elapsed
+	remaining := snapshot.Remaining
 
 	statusInfo := map[string]interface{}{
+		"name":         snapshot.TimerName,
 		"status":       status,
 		"session_type": sessionType,
+		"task":         snapshot.Task,
+		"tags":         snapshot.Tags,
 		"start_time":   startTime.Format(time.RFC3339),
 		"duration":     duration.Seconds(),
 		"elapsed":      elapsed.Seconds(),
 		"remaining":    remaining.Seconds(),
 		"progress":     progress,
+		"stopwatch":    snapshot.Stopwatch,
+		"end_time":     nil,
+		"overtime":     snapshot.Overtime.Seconds(),
+		"cycle": map[string]interface{}{
+			"pomodoros":           snapshot.Pomodoros,
+			"long_break_interval": snapshot.LongBreakInterval,
+			"next_session_type":   snapshot.NextSessionType,
+		},
 	}
 
-	if statusJSON {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(statusInfo)
+	if !snapshot.EndTime.IsZero() {
+		statusInfo["end_time"] = snapshot.EndTime.Format(time.RFC3339)
 	}
+	return statusInfo
+}
 
-	logger.Debug("Status info", statusInfo)
+// printStatus describes a timer in full.
+func printStatus(snapshot timer.Snapshot) {
+	status := snapshot.Status
+	progress := snapshot.Progress
+	sessionType := snapshot.SessionType
+	startTime := snapshot.StartTime
+	duration := snapshot.Duration
+	elapsed := snapshot.Elapsed
+	remaining := snapshot.Remaining
 
-	fmt.Printf("Status:        %s\n", status)
-	fmt.Printf("Session Type:  %s\n", sessionType)
+	th := loadTheme()
+	if snapshot.TimerName != "" {
+		fmt.Printf("Timer:         %s\n", snapshot.TimerName)
+	}
+	role := string(sessionType)
+	if status == timer.StatusPaused {
+		fmt.Printf("Status:        %s%s\n", th.Icon(theme.IconPaused), th.Paint(theme.RolePaused, string(status)))
+	} else {
+		fmt.Printf("Status:        %s\n", status)
+	}
+	fmt.Printf("Session Type:  %s%s\n", th.Icon(role), th.Paint(role, role))
+	if snapshot.Task != "" {
+		fmt.Printf("Task:          %s\n", snapshot.Task)
+	}
+	if len(snapshot.Tags) > 0 {
+		fmt.Printf("Tags:          %s\n", strings.Join(snapshot.Tags, ", "))
+	}
 	fmt.Printf("Start Time:    %s\n", startTime.Format("2006-01-02 15:04:05"))
-	fmt.Printf("Duration:      %s\n", formatDuration(duration))
-	fmt.Printf("Elapsed:       %s\n", formatDuration(elapsed))
-	fmt.Printf("Remaining:     %s\n", formatDuration(remaining))
-	fmt.Printf("Progress:      %3.0f%%\n", progress*100)
+	if snapshot.Stopwatch {
+		fmt.Printf("Duration:      open-ended (stopwatch)\n")
+		fmt.Printf("Elapsed:       %s\n", formatDuration(elapsed))
+	} else {
+		fmt.Printf("Duration:      %s\n", formatDuration(duration))
+		fmt.Printf("Elapsed:       %s\n", formatDuration(elapsed))
+		fmt.Printf("Remaining:     %s\n", formatDuration(remaining))
+		if !snapshot.EndTime.IsZero() {
+			fmt.Printf("Ends At:       %s\n", snapshot.EndTime.Format("2006-01-02 15:04:05"))
+		}
+		fmt.Printf("Progress:      %s %3.0f%%\n", th.ProgressBar(progress, 30, role), progress*100)
+	}
+	if snapshot.OvertimeMode {
+		fmt.Printf("Overtime:      %s\n", formatDuration(snapshot.Overtime))
+	}
+	fmt.Printf("Cycle:         %d/%d pomodoros (next: %s)\n", snapshot.Pomodoros, snapshot.LongBreakInterval, snapshot.NextSessionType)
 
-	return nil
+	if status == timer.StatusInterrupted {
+		fmt.Println()
+		fmt.Println("This session was interrupted by a crash or reboot.")
+		fmt.Println("Run 'pomodux recover' to record, resume or discard it.")
+	}
 }
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/rsmacapinlac/pomodux/internal/timer"
+	"github.com/spf13/cobra"
+)
+
+var stopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the current timer session",
+	Long: `Stop the current session now. The session is recorded in history as not
+completed, unless it is a stopwatch session or an overtime session that had
+already reached its planned end. Use 'pomodux skip' to move on to the next
+session in the cycle, or 'pomodux cancel' to discard the session without
+recording it.`,
+	RunE: runStop,
+}
+
+func init() {
+	addNameFlag(stopCmd)
+	rootCmd.AddCommand(stopCmd)
+}
+
+func runStop(cmd *cobra.Command, args []string) error {
+	client, err := daemonClient()
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := client.Snapshot()
+	if err != nil {
+		return fmt.Errorf("failed to get timer status: %w", err)
+	}
+	if snapshot.Status != timer.StatusRunning && snapshot.Status != timer.StatusPaused {
+		cmd.PrintErrln("Cannot stop timer: timer is not running (current status:", snapshot.Status, ")")
+		return fmt.Errorf("cannot stop timer: timer is not running (current status: %v)", snapshot.Status)
+	}
+
+	if err := client.Stop(); err != nil {
+		cmd.PrintErrln("Failed to stop timer:", err)
+		return fmt.Errorf("failed to stop timer: %w", err)
+	}
+
+	fmt.Printf("Timer stopped. %s\n", stoppedSessionStatus(snapshot))
+	return nil
+}
+
+// stoppedSessionStatus describes how the stopped session was recorded,
+// according to its history record.
+func stoppedSessionStatus(snapshot timer.Snapshot) string {
+	historyManager, err := timer.NewHistoryManager()
+	if err != nil {
+		return fmt.Sprintf("%s session recorded.", snapshot.SessionType)
+	}
+	session, err := historyManager.FindSession(snapshot.SessionID)
+	if err != nil {
+		return fmt.Sprintf("%s session recorded.", snapshot.SessionType)
+	}
+	if session.Completed {
+		return fmt.Sprintf("%s session recorded as completed.", session.Type)
+	}
+	return fmt.Sprintf("%s session recorded as not completed.", session.Type)
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"time"
+
+	"github.com/rsmacapinlac/pomodux/internal/config"
+	"github.com/rsmacapinlac/pomodux/internal/logger"
+	"github.com/rsmacapinlac/pomodux/internal/timer"
+)
+
+// streakConfigFrom builds the timer's streak settings from the configuration.
+func streakConfigFrom(cfg *config.Config) timer.StreakConfig {
+	return timer.StreakConfig{
+		MinPomodoros: cfg.Streaks.MinPomodoros,
+		DayStartHour: cfg.Streaks.DayStartHour,
+	}
+}
+
+// streakConfig reads the streak settings, falling back to the defaults when
+// the configuration cannot be loaded.
+func streakConfig() timer.StreakConfig {
+	cfg, err := loadConfig()
+	if err != nil {
+		logger.Warn("Failed to load configuration, using default streak settings", map[string]interface{}{"error": err.Error()})
+		cfg = config.DefaultConfig()
+	}
+	return streakConfigFrom(cfg)
+}
+
+// statusStreak describes the streak as of now for JSON output.
+func statusStreak() map[string]interface{} {
+	streakCfg := streakConfig()
+	var sessions []timer.SessionRecord
+	historyManager, err := timer.NewHistoryManager()
+	if err == nil {
+		sessions, err = historyManager.GetAllSessions()
+	}
+	if err != nil {
+		logger.Warn("Failed to measure streak", map[string]interface{}{"error": err.Error()})
+	}
+	return streakInfo(streakCfg, timer.Streaks(streakCfg, sessionTypes(), sessions, time.Now()))
+}
+
+// streakInfo describes a streak for JSON output.
+func streakInfo(streakCfg timer.StreakConfig, streak timer.Streak) map[string]interface{} {
+	info := map[string]interface{}{
+		"current":       streak.Current,
+		"longest":       streak.Longest,
+		"today":         streak.Today,
+		"min_pomodoros": streakCfg.MinPomodoros,
+	}
+	if !streak.LastDay.IsZero() {
+		info["last_day"] = streak.LastDay.Format("2006-01-02")
+	}
+	return info
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/rsmacapinlac/pomodux/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+var trackCmd = &cobra.Command{
+	Use:   "track [task]",
+	Short: "Start an open-ended stopwatch session",
+	Long: `Track unplanned work with a stopwatch that counts up with no target.
+
+The session can be paused and resumed like any other, and is recorded in
+history as work with the time it actually ran once it is stopped.
+
+Examples:
+  pomodux track "Production incident"
+  pomodux track "Pairing with Sam" --tag pairing`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTrack,
+}
+
+func init() {
+	addAnnotationFlags(trackCmd)
+	addDetachFlag(trackCmd)
+	addNameFlag(trackCmd)
+	rootCmd.AddCommand(trackCmd)
+}
+
+func runTrack(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		sessionTask = args[0]
+	}
+
+	client, err := daemonClient()
+	if err != nil {
+		return err
+	}
+
+	resumed, err := resolveInterrupted(client)
+	if err != nil {
+		return err
+	}
+	if resumed {
+		return runInteractive(client)
+	}
+
+	annotation := sessionAnnotation()
+	if err := client.Track(annotation); err != nil {
+		return fmt.Errorf("failed to start stopwatch: %w", err)
+	}
+	logger.Info("Stopwatch started", map[string]interface{}{"task": annotation.Task, "tags": annotation.Tags})
+
+	return runInteractive(client)
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/rsmacapinlac/pomodux/internal/daemon"
+	"github.com/rsmacapinlac/pomodux/internal/timer"
+	"github.com/rsmacapinlac/pomodux/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Open the full-screen terminal interface",
+	Long: `Open a full-screen view of the timer with today's sessions, the position in
+the Pomodoro cycle, and key hints. The keys come from tui.key_bindings in the
+configuration; the start key starts the next session in the cycle.
+
+Press Ctrl+C to quit. Quitting leaves the current session running.`,
+	Args: cobra.NoArgs,
+	RunE: runTUI,
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+}
+
+// tuiController drives the daemon's timer from the TUI.
+type tuiController struct {
+	*daemon.Client
+}
+
+// StartNext starts the next session in the cycle with no task or tags.
+func (c tuiController) StartNext() error {
+	return c.Client.StartNext(timer.Annotation{}, false)
+}
+
+func runTUI(cmd *cobra.Command, args []string) error {
+	client, err := daemonClient()
+	if err != nil {
+		return err
+	}
+
+	history, err := timer.NewHistoryManager()
+	if err != nil {
+		return fmt.Errorf("failed to open session history: %w", err)
+	}
+
+	return tui.Run(tuiController{client}, history, interactiveKeys(), sessionTypes(), loadTheme())
+}
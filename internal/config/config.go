@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
+	"unicode"
 
+	"github.com/rsmacapinlac/pomodux/internal/theme"
 	"gopkg.in/yaml.v3"
 )
 
@@ -16,9 +19,35 @@ type Config struct {
 		DefaultWorkDuration      time.Duration `yaml:"default_work_duration"`
 		DefaultBreakDuration     time.Duration `yaml:"default_break_duration"`
 		DefaultLongBreakDuration time.Duration `yaml:"default_long_break_duration"`
+		LongBreakInterval        int           `yaml:"long_break_interval"`
 		AutoStartBreaks          bool          `yaml:"auto_start_breaks"`
+		AutoStartWork            bool          `yaml:"auto_start_work"`
+		// Overtime keeps sessions counting past their planned end until stopped
+		Overtime bool `yaml:"overtime"`
 	} `yaml:"timer"`
 
+	// Goals are targets to reach each day and week; zero leaves a goal unset
+	Goals struct {
+		DailyPomodoros  int           `yaml:"daily_pomodoros"`
+		DailyFocus      time.Duration `yaml:"daily_focus"`
+		WeeklyPomodoros int           `yaml:"weekly_pomodoros"`
+		WeeklyFocus     time.Duration `yaml:"weekly_focus"`
+		// Days lists the days daily goals apply on (mon, tue, ...); every day when empty
+		Days []string `yaml:"days"`
+	} `yaml:"goals"`
+
+	// Streaks count consecutive days with at least MinPomodoros completed
+	// work sessions
+	Streaks struct {
+		MinPomodoros int `yaml:"min_pomodoros"`
+		// DayStartHour is the hour days begin at, so late-night sessions
+		// count for the previous day
+		DayStartHour int `yaml:"day_start_hour"`
+	} `yaml:"streaks"`
+
+	// SessionTypes are user-defined session types, keyed by name
+	SessionTypes map[string]SessionTypeConfig `yaml:"session_types"`
+
 	TUI struct {
 		Theme       string            `yaml:"theme"`
 		KeyBindings map[string]string `yaml:"key_bindings"`
@@ -43,6 +72,30 @@ type Config struct {
 	} `yaml:"logging"`
 }
 
+// SessionTypeConfig defines a user-defined session type. It can also be
+// written as just its duration, as in "review: 15m".
+type SessionTypeConfig struct {
+	Duration time.Duration `yaml:"duration"`
+	// Color is the theme color of the type's sessions
+	Color string `yaml:"color,omitempty"`
+	// Work makes the type's sessions count as work in goals, streaks and statistics
+	Work bool `yaml:"work"`
+	// Message is the notification text plugins get when a session completes
+	Message string `yaml:"message,omitempty"`
+}
+
+// UnmarshalYAML accepts either a duration or a full session type definition.
+func (s *SessionTypeConfig) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		return value.Decode(&s.Duration)
+	}
+	type plain SessionTypeConfig
+	return value.Decode((*plain)(s))
+}
+
+// builtinSessionTypes are the session types of the Pomodoro cycle.
+var builtinSessionTypes = map[string]bool{"work": true, "break": true, "long-break": true}
+
 // DefaultConfig returns a new Config with default values
 func DefaultConfig() *Config {
 	config := &Config{}
@@ -51,16 +104,18 @@ func DefaultConfig() *Config {
 	config.Timer.DefaultWorkDuration = 25 * time.Minute
 	config.Timer.DefaultBreakDuration = 5 * time.Minute
 	config.Timer.DefaultLongBreakDuration = 15 * time.Minute
+	config.Timer.LongBreakInterval = 4
 	config.Timer.AutoStartBreaks = false
+	config.Timer.AutoStartWork = false
+	config.Timer.Overtime = false
+
+	// Streak defaults
+	config.Streaks.MinPomodoros = 1
+	config.Streaks.DayStartHour = 0
 
 	// TUI defaults
 	config.TUI.Theme = "default"
-	config.TUI.KeyBindings = map[string]string{
-		"start":  "s",
-		"stop":   "q",
-		"pause":  "p",
-		"resume": "r",
-	}
+	config.TUI.KeyBindings = DefaultKeyBindings()
 
 	// Notification defaults
 	config.Notifications.Enabled = true
@@ -80,6 +135,146 @@ func DefaultConfig() *Config {
 	return config
 }
 
+// DefaultKeyBindings returns the default key for each action.
+func DefaultKeyBindings() map[string]string {
+	return map[string]string{
+		"start":          "s",
+		"stop":           "q",
+		"pause":          "p",
+		"resume":         "r",
+		"skip":           "n",
+		"extend":         "e",
+		"note":           "m",
+		"toggle_display": "d",
+	}
+}
+
+// parse reads a configuration file's contents over the defaults.
+func parse(data []byte) (*Config, error) {
+	config := DefaultConfig()
+	config.TUI.KeyBindings = nil
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	config.TUI.KeyBindings = withDefaultKeyBindings(config.TUI.KeyBindings)
+	return config, nil
+}
+
+// withDefaultKeyBindings adds the default binding of every action the user
+// did not bind, unless the user bound its key to another action. Configs
+// written before an action existed may already use its default key.
+func withDefaultKeyBindings(bindings map[string]string) map[string]string {
+	taken := make(map[string]bool, len(bindings))
+	for _, key := range bindings {
+		taken[key] = true
+	}
+	merged := make(map[string]string, len(KeyActions))
+	for action, key := range DefaultKeyBindings() {
+		if _, ok := bindings[action]; !ok && !taken[key] {
+			merged[action] = key
+		}
+	}
+	for action, key := range bindings {
+		merged[action] = key
+	}
+	return merged
+}
+
+// KeyActions are the actions that can be bound to keys in tui.key_bindings.
+var KeyActions = []string{"start", "stop", "pause", "resume", "skip", "extend", "note", "toggle_display"}
+
+// validateKeyBindings checks that every binding is for a known action, is a
+// single printable character, and is not shared with another action.
+func validateKeyBindings(bindings map[string]string) error {
+	known := make(map[string]bool, len(KeyActions))
+	for _, action := range KeyActions {
+		known[action] = true
+	}
+
+	actions := make([]string, 0, len(bindings))
+	for action := range bindings {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	boundTo := make(map[string]string, len(bindings))
+	for _, action := range actions {
+		key := bindings[action]
+		if !known[action] {
+			return fmt.Errorf("unknown key binding action: %s (valid: %s)", action, strings.Join(KeyActions, ", "))
+		}
+		if len(key) != 1 || key[0] <= ' ' || key[0] > '~' {
+			return fmt.Errorf("key binding for %s must be a single printable character, got %q", action, key)
+		}
+		if other, ok := boundTo[key]; ok {
+			return fmt.Errorf("key %q is bound to both %s and %s", key, other, action)
+		}
+		boundTo[key] = action
+	}
+	return nil
+}
+
+// weekdays maps the day names accepted in goals.days to weekdays.
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// ParseWeekdays converts day names such as "mon" or "Monday" to weekdays.
+func ParseWeekdays(names []string) ([]time.Weekday, error) {
+	days := make([]time.Weekday, 0, len(names))
+	for _, name := range names {
+		key := strings.ToLower(strings.TrimSpace(name))
+		day, ok := weekdays[key]
+		if !ok && len(key) > 3 {
+			day, ok = weekdays[key[:3]]
+			ok = ok && key == strings.ToLower(day.String())
+		}
+		if !ok {
+			return nil, fmt.Errorf("unknown day: %s", name)
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// validateGoals checks that no goal is negative and every goal day is known.
+func validateGoals(config *Config) error {
+	goals := config.Goals
+	if goals.DailyPomodoros < 0 || goals.WeeklyPomodoros < 0 || goals.DailyFocus < 0 || goals.WeeklyFocus < 0 {
+		return fmt.Errorf("goals must not be negative")
+	}
+	if _, err := ParseWeekdays(goals.Days); err != nil {
+		return fmt.Errorf("invalid goals.days: %w", err)
+	}
+	return nil
+}
+
+// validateSessionTypes checks that every user-defined session type has a
+// usable name, a positive duration and a known color.
+func validateSessionTypes(config *Config) error {
+	for name, sessionType := range config.SessionTypes {
+		if builtinSessionTypes[name] {
+			return fmt.Errorf("session type %q is built in and cannot be redefined", name)
+		}
+		if name == "" || len(name) > 32 {
+			return fmt.Errorf("session type names must be 1 to 32 characters")
+		}
+		for _, r := range name {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' && r != '.' {
+				return fmt.Errorf("invalid session type name %q: use letters, digits, '-', '_' or '.'", name)
+			}
+		}
+		if sessionType.Duration <= 0 {
+			return fmt.Errorf("session type %q needs a positive duration", name)
+		}
+		if err := theme.ValidateColor(sessionType.Color); err != nil {
+			return fmt.Errorf("session type %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
 // defaultPluginsDir returns the default plugins directory (XDG_CONFIG_HOME/pomodux/plugins)
 func defaultPluginsDir() string {
 	configHome := os.Getenv("XDG_CONFIG_HOME")
@@ -115,9 +310,9 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	config := DefaultConfig()
-	if err := yaml.Unmarshal(data, config); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	config, err := parse(data)
+	if err != nil {
+		return nil, err
 	}
 
 	// Ensure plugins directory is set
@@ -189,9 +384,9 @@ func LoadFromPath(path string) (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	config := DefaultConfig()
-	if err := yaml.Unmarshal(data, config); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	config, err := parse(data)
+	if err != nil {
+		return nil, err
 	}
 
 	// Ensure plugins directory is set
@@ -232,6 +427,26 @@ func Validate(config *Config) error {
 	if config.Timer.DefaultLongBreakDuration <= 0 {
 		return fmt.Errorf("default long break duration must be positive")
 	}
+	if config.Timer.LongBreakInterval < 1 {
+		return fmt.Errorf("long break interval must be at least 1")
+	}
+
+	if err := validateKeyBindings(config.TUI.KeyBindings); err != nil {
+		return err
+	}
+
+	if err := validateGoals(config); err != nil {
+		return err
+	}
+	if err := validateSessionTypes(config); err != nil {
+		return err
+	}
+	if config.Streaks.MinPomodoros < 1 {
+		return fmt.Errorf("streaks.min_pomodoros must be at least 1")
+	}
+	if config.Streaks.DayStartHour < 0 || config.Streaks.DayStartHour > 23 {
+		return fmt.Errorf("streaks.day_start_hour must be between 0 and 23")
+	}
 
 	// Validate logging configuration
 	if config.Logging.Level != "" {
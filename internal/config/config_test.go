@@ -22,10 +22,18 @@ func TestDefaultConfig(t *testing.T) {
 		t.Errorf("expected default break duration 5m, got %v", config.Timer.DefaultBreakDuration)
 	}
 
+	if config.Timer.LongBreakInterval != 4 {
+		t.Errorf("expected long break interval 4, got %d", config.Timer.LongBreakInterval)
+	}
+
 	if config.Timer.AutoStartBreaks {
 		t.Error("expected auto start breaks to be false by default")
 	}
 
+	if config.Timer.AutoStartWork {
+		t.Error("expected auto start work to be false by default")
+	}
+
 	// Test TUI defaults
 	if config.TUI.Theme != "default" {
 		t.Errorf("expected default theme 'default', got %s", config.TUI.Theme)
@@ -79,6 +87,53 @@ func TestConfigValidation(t *testing.T) {
 		t.Error("expected validation to fail with negative break duration")
 	}
 
+	// Test invalid long break interval
+	config = DefaultConfig()
+	config.Timer.LongBreakInterval = 0
+	if err := Validate(config); err == nil {
+		t.Error("expected validation to fail with zero long break interval")
+	}
+
+	// Test conflicting key bindings
+	config = DefaultConfig()
+	config.TUI.KeyBindings["skip"] = "p"
+	if err := Validate(config); err == nil || !strings.Contains(err.Error(), "bound to both") {
+		t.Errorf("expected validation to fail with conflicting key bindings, got %v", err)
+	}
+
+	// Test invalid key bindings
+	for action, key := range map[string]string{"pause": "pp", "resume": " ", "launch": "l"} {
+		config = DefaultConfig()
+		config.TUI.KeyBindings[action] = key
+		if err := Validate(config); err == nil {
+			t.Errorf("expected validation to fail with key binding %s=%q", action, key)
+		}
+	}
+
+	// Test invalid goals
+	config = DefaultConfig()
+	config.Goals.Days = []string{"mon", "monkey"}
+	if err := Validate(config); err == nil || !strings.Contains(err.Error(), "monkey") {
+		t.Errorf("expected validation to fail with an unknown goal day, got %v", err)
+	}
+	config = DefaultConfig()
+	config.Goals.WeeklyFocus = -time.Hour
+	if err := Validate(config); err == nil {
+		t.Error("expected validation to fail with a negative goal")
+	}
+
+	// Test invalid streak settings
+	config = DefaultConfig()
+	config.Streaks.DayStartHour = 24
+	if err := Validate(config); err == nil {
+		t.Error("expected validation to fail with a day start hour of 24")
+	}
+	config = DefaultConfig()
+	config.Streaks.MinPomodoros = 0
+	if err := Validate(config); err == nil {
+		t.Error("expected validation to fail with no minimum pomodoros")
+	}
+
 	// Test invalid log level
 	config = DefaultConfig()
 	config.Logging.Level = "invalid"
@@ -87,6 +142,22 @@ func TestConfigValidation(t *testing.T) {
 	}
 }
 
+func TestParseWeekdays(t *testing.T) {
+	days, err := ParseWeekdays([]string{"Mon", "tuesday", "sat"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []time.Weekday{time.Monday, time.Tuesday, time.Saturday}
+	if len(days) != len(want) {
+		t.Fatalf("expected %v, got %v", want, days)
+	}
+	for i := range want {
+		if days[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, days)
+		}
+	}
+}
+
 func TestConfigPath(t *testing.T) {
 	path, err := getConfigPath()
 	if err != nil {
@@ -173,6 +244,66 @@ func TestLoadConfigWithInvalidYAML(t *testing.T) {
 	}
 }
 
+func TestLoadKeepsKeysBoundBeforeNewActions(t *testing.T) {
+	// Written before skip took "n" by default
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("tui:\n  key_bindings:\n    pause: n\n"), 0600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	config, err := LoadFromPath(path)
+	if err != nil {
+		t.Fatalf("expected an older config to stay valid, got %v", err)
+	}
+	bindings := config.TUI.KeyBindings
+	if bindings["pause"] != "n" {
+		t.Errorf("expected pause on n, got %q", bindings["pause"])
+	}
+	if key, ok := bindings["skip"]; ok {
+		t.Errorf("expected skip to lose its default key, got %q", key)
+	}
+	if bindings["stop"] != "q" || bindings["extend"] != "e" {
+		t.Errorf("expected other actions to keep their defaults, got %v", bindings)
+	}
+}
+
+func TestSessionTypes(t *testing.T) {
+	data := `session_types:
+  standup: 10m
+  review:
+    duration: 15m
+    color: magenta
+    work: true
+    message: "Review done"`
+
+	config := DefaultConfig()
+	if err := yaml.Unmarshal([]byte(data), config); err != nil {
+		t.Fatalf("failed to parse session types: %v", err)
+	}
+	if standup := config.SessionTypes["standup"]; standup.Duration != 10*time.Minute || standup.Work {
+		t.Errorf("expected a 10m stand-up that is not work, got %+v", standup)
+	}
+	if review := config.SessionTypes["review"]; review.Duration != 15*time.Minute || !review.Work || review.Color != "magenta" || review.Message != "Review done" {
+		t.Errorf("expected the full review definition, got %+v", review)
+	}
+	if err := Validate(config); err != nil {
+		t.Errorf("expected session types to be valid, got %v", err)
+	}
+
+	for name, sessionType := range map[string]SessionTypeConfig{
+		"break":       {Duration: time.Minute},
+		"code review": {Duration: time.Minute},
+		"nothing":     {},
+		"colorful":    {Duration: time.Minute, Color: "plaid"},
+	} {
+		config := DefaultConfig()
+		config.SessionTypes = map[string]SessionTypeConfig{name: sessionType}
+		if err := Validate(config); err == nil {
+			t.Errorf("expected session type %q %+v to be invalid", name, sessionType)
+		}
+	}
+}
+
 func TestConfigPathWithXDGConfigHome(t *testing.T) {
 	// Save original environment
 	originalXDGConfigHome := os.Getenv("XDG_CONFIG_HOME")
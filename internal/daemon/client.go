@@ -0,0 +1,222 @@
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/rsmacapinlac/pomodux/internal/timer"
+)
+
+// startupTimeout is how long Connect waits for a freshly spawned daemon
+const startupTimeout = 5 * time.Second
+
+// Client sends requests to the daemon. It implements timer.Controller for
+// the default timer, or for a named timer when created by Named.
+type Client struct {
+	socketPath string
+	// name selects a named timer; empty for the default timer
+	name string
+}
+
+// NewClient creates a client for the daemon listening on socketPath.
+func NewClient(socketPath string) *Client {
+	return &Client{socketPath: socketPath}
+}
+
+// Connect returns a client for the daemon on socketPath, starting the daemon
+// in the background with the given command-line arguments if it is not running.
+func Connect(socketPath string, args []string) (*Client, error) {
+	client := NewClient(socketPath)
+	if client.Ping() == nil {
+		return client, nil
+	}
+
+	if err := Spawn(args); err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(startupTimeout)
+	for time.Now().Before(deadline) {
+		if client.Ping() == nil {
+			return client, nil
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	return nil, fmt.Errorf("daemon did not start within %v", startupTimeout)
+}
+
+// Spawn starts the current executable with args as a detached background process.
+func Spawn(args []string) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find executable: %w", err)
+	}
+
+	cmd := exec.Command(exe, args...) // #nosec G204 -- runs our own executable
+	cmd.SysProcAttr = detachedProcAttr()
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start daemon: %w", err)
+	}
+
+	return cmd.Process.Release()
+}
+
+// Named returns a client for the timer called name. An empty name is the
+// default timer.
+func (c *Client) Named(name string) *Client {
+	return &Client{socketPath: c.socketPath, name: name}
+}
+
+// Ping checks that the daemon is reachable.
+func (c *Client) Ping() error {
+	_, err := c.call(Request{Action: ActionPing})
+	return err
+}
+
+// Start starts a session of the given type and duration. With overtime, the
+// session keeps counting past its planned end until it is stopped.
+func (c *Client) Start(duration time.Duration, sessionType timer.SessionType, annotation timer.Annotation, overtime bool) error {
+	_, err := c.call(Request{Action: ActionStart, Duration: duration, SessionType: sessionType, Annotation: annotation, Overtime: overtime})
+	return err
+}
+
+// StartNext starts the next session in the Pomodoro cycle.
+func (c *Client) StartNext(annotation timer.Annotation, overtime bool) error {
+	_, err := c.call(Request{Action: ActionNext, Annotation: annotation, Overtime: overtime})
+	return err
+}
+
+// Track starts an open-ended stopwatch session.
+func (c *Client) Track(annotation timer.Annotation) error {
+	_, err := c.call(Request{Action: ActionTrack, Annotation: annotation})
+	return err
+}
+
+// Pause pauses the running session.
+func (c *Client) Pause() error {
+	return c.PauseWithReason("")
+}
+
+// PauseWithReason pauses the running session, recording why it was interrupted.
+func (c *Client) PauseWithReason(reason string) error {
+	_, err := c.call(Request{Action: ActionPause, Reason: reason})
+	return err
+}
+
+// Resume resumes the paused session.
+func (c *Client) Resume() error {
+	_, err := c.call(Request{Action: ActionResume})
+	return err
+}
+
+// Stop stops the current session.
+func (c *Client) Stop() error {
+	_, err := c.call(Request{Action: ActionStop})
+	return err
+}
+
+// Skip ends the current session and starts the next one in the cycle.
+func (c *Client) Skip() error {
+	_, err := c.call(Request{Action: ActionSkip})
+	return err
+}
+
+// Cancel discards the current session without recording it.
+func (c *Client) Cancel() error {
+	_, err := c.call(Request{Action: ActionCancel})
+	return err
+}
+
+// Extend adds time to the planned duration of the current session, or takes
+// it away when by is negative.
+func (c *Client) Extend(by time.Duration) error {
+	_, err := c.call(Request{Action: ActionExtend, Duration: by})
+	return err
+}
+
+// AddNote adds a note to the current session.
+func (c *Client) AddNote(text string) error {
+	_, err := c.call(Request{Action: ActionNote, Note: text})
+	return err
+}
+
+// Recover resolves a session interrupted by a crash or reboot.
+func (c *Client) Recover(action timer.RecoveryAction) error {
+	_, err := c.call(Request{Action: ActionRecover, Recovery: action})
+	return err
+}
+
+// Snapshot returns the current state of the daemon's timer.
+func (c *Client) Snapshot() (timer.Snapshot, error) {
+	snapshot, err := c.call(Request{Action: ActionStatus})
+	if err != nil {
+		return timer.Snapshot{}, err
+	}
+	return *snapshot, nil
+}
+
+// List returns a snapshot of every timer: the default timer first, then the
+// named timers by name.
+func (c *Client) List() ([]timer.Snapshot, error) {
+	resp, err := c.roundTrip(Request{Action: ActionList})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+	return resp.Timers, nil
+}
+
+// Shutdown asks the daemon to exit.
+func (c *Client) Shutdown() error {
+	_, err := c.call(Request{Action: ActionShutdown})
+	return err
+}
+
+// call sends a request for the client's timer and waits for the response.
+func (c *Client) call(req Request) (*timer.Snapshot, error) {
+	req.Name = c.name
+	resp, err := c.roundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return resp.Status, errors.New(resp.Error)
+	}
+	if resp.Status == nil {
+		return nil, fmt.Errorf("daemon returned no status")
+	}
+
+	return resp.Status, nil
+}
+
+// roundTrip sends a request and reads the response.
+func (c *Client) roundTrip(req Request) (*Response, error) {
+	conn, err := net.DialTimeout("unix", c.socketPath, requestTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to daemon: %w", err)
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(requestTimeout)); err != nil {
+		return nil, fmt.Errorf("failed to set connection deadline: %w", err)
+	}
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	var resp Response
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return &resp, nil
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+
+package daemon
+
+import "syscall"
+
+// detachedProcAttr starts the daemon in its own session so that it survives
+// the terminal that spawned it being closed.
+func detachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
@@ -0,0 +1,14 @@
+//go:build windows
+
+package daemon
+
+import "syscall"
+
+// detachedProcess is the DETACHED_PROCESS process creation flag
+const detachedProcess = 0x00000008
+
+// detachedProcAttr starts the daemon without a console so that it survives
+// the terminal that spawned it being closed.
+func detachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP | detachedProcess}
+}
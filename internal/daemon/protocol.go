@@ -0,0 +1,74 @@
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rsmacapinlac/pomodux/internal/timer"
+)
+
+// Action identifies the operation a client asks the daemon to perform.
+type Action string
+
+const (
+	ActionPing     Action = "ping"
+	ActionStatus   Action = "status"
+	ActionList     Action = "list"
+	ActionStart    Action = "start"
+	ActionNext     Action = "next"
+	ActionTrack    Action = "track"
+	ActionPause    Action = "pause"
+	ActionResume   Action = "resume"
+	ActionStop     Action = "stop"
+	ActionSkip     Action = "skip"
+	ActionCancel   Action = "cancel"
+	ActionExtend   Action = "extend"
+	ActionNote     Action = "note"
+	ActionRecover  Action = "recover"
+	ActionShutdown Action = "shutdown"
+)
+
+// Request is a single command sent from a client to the daemon.
+// Each connection carries exactly one request and one response.
+type Request struct {
+	Action Action `json:"action"`
+	// Name selects a named timer; empty for the default timer
+	Name        string               `json:"name,omitempty"`
+	Duration    time.Duration        `json:"duration,omitempty"`
+	SessionType timer.SessionType    `json:"session_type,omitempty"`
+	Recovery    timer.RecoveryAction `json:"recovery,omitempty"`
+	// Reason says why a session is being paused
+	Reason string `json:"reason,omitempty"`
+	// Note is the text of a note added to the session
+	Note string `json:"note,omitempty"`
+	// Annotation describes what a started session is spent on
+	timer.Annotation
+	// Overtime keeps a started session counting past its planned end
+	Overtime bool `json:"overtime,omitempty"`
+}
+
+// Response is the daemon's reply to a Request. Status reflects the timer
+// the request was for after it was handled, even when Error is set, unless
+// there is no such timer.
+type Response struct {
+	Error  string          `json:"error,omitempty"`
+	Status *timer.Snapshot `json:"status,omitempty"`
+	// Timers lists every timer, the default timer first, for ActionList
+	Timers []timer.Snapshot `json:"timers,omitempty"`
+}
+
+// requestTimeout bounds how long a single request may take on either side
+const requestTimeout = 5 * time.Second
+
+// SocketPath returns the path of the daemon's Unix domain socket. It lives in
+// $XDG_RUNTIME_DIR/pomodux, or in a per-user directory under the system temp
+// directory when XDG_RUNTIME_DIR is not set.
+func SocketPath() string {
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	if runtimeDir == "" {
+		return filepath.Join(os.TempDir(), fmt.Sprintf("pomodux-%d", os.Getuid()), "pomodux.sock")
+	}
+	return filepath.Join(runtimeDir, "pomodux", "pomodux.sock")
+}
@@ -0,0 +1,314 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rsmacapinlac/pomodux/internal/logger"
+	"github.com/rsmacapinlac/pomodux/internal/timer"
+)
+
+// completionCheckInterval is how often the daemon checks whether the running
+// session has finished, so completion is recorded without any client attached.
+const completionCheckInterval = 500 * time.Millisecond
+
+// Server owns a Timer and serves requests for it over a Unix domain socket.
+// Once named timers are enabled it also owns a timer for each name in use.
+type Server struct {
+	timer *timer.Timer
+	// named holds the named timers with sessions, by name
+	named map[string]*timer.Timer
+	// newTimer creates a named timer; nil until named timers are enabled
+	newTimer     func(name string) *timer.Timer
+	mu           sync.Mutex
+	socketPath   string
+	shutdown     chan struct{}
+	shutdownOnce sync.Once
+}
+
+// NewServer creates a server for t that will listen on socketPath.
+func NewServer(t *timer.Timer, socketPath string) *Server {
+	return &Server{
+		timer:      t,
+		named:      map[string]*timer.Timer{},
+		socketPath: socketPath,
+		shutdown:   make(chan struct{}),
+	}
+}
+
+// EnableNamedTimers lets clients run named timers alongside the default one.
+// newTimer creates the timer for a name. Timers are created straight away for
+// existing, the names with sessions left in the state file, so those sessions
+// complete or are found to be interrupted.
+func (s *Server) EnableNamedTimers(newTimer func(name string) *timer.Timer, existing []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.newTimer = newTimer
+	for _, name := range existing {
+		s.named[name] = newTimer(name)
+	}
+}
+
+// timerFor returns the timer a request is for. Asking for the status of a
+// named timer that does not exist returns nil; sessions are started on new
+// named timers by startOn.
+func (s *Server) timerFor(req Request) (*timer.Timer, error) {
+	if req.Name == "" || req.Name == timer.DefaultTimerName {
+		return s.timer, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.newTimer == nil {
+		return nil, fmt.Errorf("named timers are not supported")
+	}
+	if t, ok := s.named[req.Name]; ok {
+		return t, nil
+	}
+
+	switch req.Action {
+	case ActionPing, ActionStatus:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("no timer named %q", req.Name)
+	}
+}
+
+// startOn starts the session a start, next or track request asks for and
+// returns the timer it runs on. A named timer is created if the name is not
+// in use. Named sessions are started under s.mu, so pruneNamed cannot forget
+// a timer between finding it idle and its session starting.
+func (s *Server) startOn(req Request) (*timer.Timer, error) {
+	if req.Name == "" || req.Name == timer.DefaultTimerName {
+		return s.timer, startSession(s.timer, req)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.newTimer == nil {
+		return nil, fmt.Errorf("named timers are not supported")
+	}
+	t, ok := s.named[req.Name]
+	if !ok {
+		if err := timer.ValidateTimerName(req.Name); err != nil {
+			return nil, err
+		}
+		t = s.newTimer(req.Name)
+	}
+	if err := startSession(t, req); err != nil {
+		return t, err
+	}
+	s.named[req.Name] = t
+	return t, nil
+}
+
+// startSession starts the session a start, next or track request asks for on t.
+func startSession(t *timer.Timer, req Request) error {
+	switch req.Action {
+	case ActionNext:
+		return t.StartNext(req.Annotation, req.Overtime)
+	case ActionTrack:
+		return t.StartStopwatch(req.Annotation)
+	default:
+		return t.StartSession(req.Duration, req.SessionType, req.Annotation, req.Overtime)
+	}
+}
+
+// timers returns every timer, the default timer first and then the named
+// timers sorted by name.
+func (s *Server) timers() []*timer.Timer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := make([]string, 0, len(s.named))
+	for name := range s.named {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	timers := []*timer.Timer{s.timer}
+	for _, name := range names {
+		timers = append(timers, s.named[name])
+	}
+	return timers
+}
+
+// pruneNamed forgets named timers whose sessions have ended.
+func (s *Server) pruneNamed() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for name, t := range s.named {
+		if status := t.GetStatus(); status == timer.StatusIdle || status == timer.StatusCompleted {
+			delete(s.named, name)
+		}
+	}
+}
+
+// ListenAndServe serves requests until ctx is cancelled or a client asks the
+// daemon to shut down. It fails if another daemon is already listening.
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	listener, err := s.listen()
+	if err != nil {
+		return err
+	}
+	defer os.Remove(s.socketPath)
+
+	logger.Info("Daemon listening", map[string]interface{}{"socket": s.socketPath})
+
+	stopped := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-s.shutdown:
+		}
+		close(stopped)
+		if err := listener.Close(); err != nil {
+			logger.Warn("Failed to close daemon listener", map[string]interface{}{"error": err.Error()})
+		}
+	}()
+
+	go s.watchCompletion(stopped)
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			select {
+			case <-stopped:
+				logger.Info("Daemon stopped")
+				return nil
+			default:
+				return fmt.Errorf("failed to accept connection: %w", err)
+			}
+		}
+		go s.serveConn(conn)
+	}
+}
+
+// listen creates the socket, replacing a stale socket left by a daemon that died.
+func (s *Server) listen() (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(s.socketPath), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create runtime directory: %w", err)
+	}
+
+	if _, err := os.Stat(s.socketPath); err == nil {
+		if NewClient(s.socketPath).Ping() == nil {
+			return nil, fmt.Errorf("daemon already running on %s", s.socketPath)
+		}
+		if err := os.Remove(s.socketPath); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+	}
+
+	listener, err := net.Listen("unix", s.socketPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", s.socketPath, err)
+	}
+	if err := os.Chmod(s.socketPath, 0600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to set socket permissions: %w", err)
+	}
+
+	return listener, nil
+}
+
+// watchCompletion polls the timers so that sessions complete, are recorded and
+// notify plugins even when no client is watching. Polling also keeps the
+// timer's ownership heartbeat fresh, which covers the named timers too.
+func (s *Server) watchCompletion(stopped <-chan struct{}) {
+	ticker := time.NewTicker(completionCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopped:
+			return
+		case <-ticker.C:
+			s.timer.GetStatus()
+			s.pruneNamed()
+		}
+	}
+}
+
+// serveConn reads one request from conn and writes the response.
+func (s *Server) serveConn(conn net.Conn) {
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(requestTimeout)); err != nil {
+		logger.Warn("Failed to set connection deadline", map[string]interface{}{"error": err.Error()})
+	}
+
+	var req Request
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		logger.Warn("Failed to decode daemon request", map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	resp := s.handle(req)
+	if err := json.NewEncoder(conn).Encode(resp); err != nil {
+		logger.Warn("Failed to encode daemon response", map[string]interface{}{"error": err.Error()})
+	}
+}
+
+// handle applies a request to the timer it is for.
+func (s *Server) handle(req Request) Response {
+	logger.Debug("Daemon request", map[string]interface{}{"action": req.Action, "name": req.Name})
+
+	if req.Action == ActionList {
+		var resp Response
+		for _, t := range s.timers() {
+			resp.Timers = append(resp.Timers, t.Snapshot())
+		}
+		return resp
+	}
+
+	var t *timer.Timer
+	var err error
+	switch req.Action {
+	case ActionStart, ActionNext, ActionTrack:
+		t, err = s.startOn(req)
+	default:
+		t, err = s.timerFor(req)
+	}
+	if t == nil {
+		if err != nil {
+			return Response{Error: err.Error()}
+		}
+		return Response{Status: &timer.Snapshot{TimerName: req.Name, Status: timer.StatusIdle}}
+	}
+
+	switch req.Action {
+	case ActionPing, ActionStatus, ActionStart, ActionNext, ActionTrack:
+	case ActionPause:
+		err = t.PauseWithReason(req.Reason)
+	case ActionResume:
+		err = t.Resume()
+	case ActionStop:
+		err = t.Stop()
+	case ActionSkip:
+		err = t.Skip()
+	case ActionCancel:
+		err = t.Cancel()
+	case ActionExtend:
+		err = t.Extend(req.Duration)
+	case ActionNote:
+		err = t.AddNote(req.Note)
+	case ActionRecover:
+		err = t.Recover(req.Recovery)
+	case ActionShutdown:
+		s.shutdownOnce.Do(func() { close(s.shutdown) })
+	default:
+		err = fmt.Errorf("unknown action: %s", req.Action)
+	}
+
+	snapshot := t.Snapshot()
+	resp := Response{Status: &snapshot}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	return resp
+}
@@ -0,0 +1,235 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rsmacapinlac/pomodux/internal/logger"
+	"github.com/rsmacapinlac/pomodux/internal/timer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMain initializes the logger for all tests in this package
+func TestMain(m *testing.M) {
+	logConfig := &logger.Config{
+		Level:      logger.LogLevelDebug,
+		Format:     "text",
+		Output:     "console",
+		ShowCaller: false,
+	}
+	if err := logger.Init(logConfig); err != nil {
+		panic("Failed to initialize logger for tests: " + err.Error())
+	}
+
+	os.Exit(m.Run())
+}
+
+// startTestServer runs a daemon for in-memory timers and returns a client for it.
+func startTestServer(t *testing.T) (*Client, <-chan error) {
+	t.Helper()
+	socketPath := filepath.Join(t.TempDir(), "pomodux.sock")
+	server := NewServer(timer.NewTimer(), socketPath)
+	server.EnableNamedTimers(func(string) *timer.Timer { return timer.NewTimer() }, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- server.ListenAndServe(ctx)
+	}()
+	t.Cleanup(cancel)
+
+	client := NewClient(socketPath)
+	require.Eventually(t, func() bool { return client.Ping() == nil }, 2*time.Second, 10*time.Millisecond)
+	return client, errChan
+}
+
+func TestServer_TimerLifecycle(t *testing.T) {
+	client, _ := startTestServer(t)
+
+	snapshot, err := client.Snapshot()
+	require.NoError(t, err)
+	assert.Equal(t, timer.StatusIdle, snapshot.Status)
+
+	require.NoError(t, client.Start(time.Minute, timer.SessionTypeBreak, timer.Annotation{}, false))
+	snapshot, err = client.Snapshot()
+	require.NoError(t, err)
+	assert.Equal(t, timer.StatusRunning, snapshot.Status)
+	assert.Equal(t, timer.SessionTypeBreak, snapshot.SessionType)
+	assert.Equal(t, time.Minute, snapshot.Duration)
+
+	require.NoError(t, client.Pause())
+	snapshot, err = client.Snapshot()
+	require.NoError(t, err)
+	assert.Equal(t, timer.StatusPaused, snapshot.Status)
+
+	require.NoError(t, client.Resume())
+	require.NoError(t, client.Stop())
+	snapshot, err = client.Snapshot()
+	require.NoError(t, err)
+	assert.Equal(t, timer.StatusIdle, snapshot.Status)
+}
+
+func TestServer_SkipAndCancel(t *testing.T) {
+	client, _ := startTestServer(t)
+
+	require.NoError(t, client.Start(time.Minute, timer.SessionTypeWork, timer.Annotation{}, false))
+	require.NoError(t, client.Skip())
+	snapshot, err := client.Snapshot()
+	require.NoError(t, err)
+	assert.Equal(t, timer.StatusRunning, snapshot.Status)
+	assert.Equal(t, timer.SessionTypeBreak, snapshot.SessionType)
+
+	require.NoError(t, client.Cancel())
+	snapshot, err = client.Snapshot()
+	require.NoError(t, err)
+	assert.Equal(t, timer.StatusIdle, snapshot.Status)
+	assert.Error(t, client.Cancel())
+}
+
+func TestServer_ReturnsTimerErrors(t *testing.T) {
+	client, _ := startTestServer(t)
+
+	err := client.Pause()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timer not running")
+
+	require.NoError(t, client.Start(time.Minute, timer.SessionTypeWork, timer.Annotation{}, false))
+	err = client.Start(time.Minute, timer.SessionTypeWork, timer.Annotation{}, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timer already running")
+}
+
+func TestServer_CompletesSession(t *testing.T) {
+	client, _ := startTestServer(t)
+
+	require.NoError(t, client.Start(50*time.Millisecond, timer.SessionTypeWork, timer.Annotation{}, false))
+	assert.Eventually(t, func() bool {
+		snapshot, err := client.Snapshot()
+		return err == nil && snapshot.Status == timer.StatusCompleted
+	}, 2*time.Second, 20*time.Millisecond)
+}
+
+func TestServer_NamedTimers(t *testing.T) {
+	client, _ := startTestServer(t)
+	deploy := client.Named("deploy")
+
+	err := deploy.Pause()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `no timer named "deploy"`)
+	snapshot, err := deploy.Snapshot()
+	require.NoError(t, err)
+	assert.Equal(t, timer.StatusIdle, snapshot.Status)
+
+	require.NoError(t, client.Start(25*time.Minute, timer.SessionTypeWork, timer.Annotation{}, false))
+	require.NoError(t, deploy.Start(10*time.Minute, timer.SessionTypeWork, timer.Annotation{Task: "Check deploy"}, false))
+	require.NoError(t, deploy.Pause())
+
+	snapshot, err = client.Snapshot()
+	require.NoError(t, err)
+	assert.Equal(t, timer.StatusRunning, snapshot.Status)
+	assert.Equal(t, 25*time.Minute, snapshot.Duration)
+
+	snapshots, err := client.List()
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, 25*time.Minute, snapshots[0].Duration)
+	assert.Equal(t, timer.StatusPaused, snapshots[1].Status)
+	assert.Equal(t, "Check deploy", snapshots[1].Task)
+
+	// The default timer can also be addressed by name
+	snapshot, err = client.Named(timer.DefaultTimerName).Snapshot()
+	require.NoError(t, err)
+	assert.Equal(t, 25*time.Minute, snapshot.Duration)
+
+	err = client.Named("bad name").Start(time.Minute, timer.SessionTypeWork, timer.Annotation{}, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid timer name")
+
+	// A named timer is forgotten once its session ends
+	require.NoError(t, deploy.Stop())
+	assert.Eventually(t, func() bool {
+		snapshots, err := client.List()
+		return err == nil && len(snapshots) == 1
+	}, 2*time.Second, 20*time.Millisecond)
+}
+
+func TestServer_StartsNamedTimersWhilePruning(t *testing.T) {
+	server := NewServer(timer.NewTimer(), filepath.Join(t.TempDir(), "pomodux.sock"))
+	server.EnableNamedTimers(func(string) *timer.Timer { return timer.NewTimer() }, nil)
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				server.pruneNamed()
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				name := fmt.Sprintf("timer-%d-%d", worker, i)
+				server.handle(Request{Action: ActionStart, Name: name, Duration: time.Minute, SessionType: timer.SessionTypeWork})
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	resp := server.handle(Request{Action: ActionList})
+	assert.Len(t, resp.Timers, 1+8*100, "expected no named timer to be forgotten while starting")
+}
+
+func TestServer_Shutdown(t *testing.T) {
+	client, errChan := startTestServer(t)
+
+	require.NoError(t, client.Shutdown())
+	select {
+	case err := <-errChan:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("server did not stop after shutdown request")
+	}
+	assert.Error(t, client.Ping())
+}
+
+func TestServer_RefusesSecondInstance(t *testing.T) {
+	client, _ := startTestServer(t)
+
+	second := NewServer(timer.NewTimer(), client.socketPath)
+	err := second.ListenAndServe(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already running")
+}
+
+func TestServer_ReplacesStaleSocket(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "pomodux.sock")
+	require.NoError(t, os.WriteFile(socketPath, nil, 0600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = NewServer(timer.NewTimer(), socketPath).ListenAndServe(ctx)
+	}()
+
+	client := NewClient(socketPath)
+	assert.Eventually(t, func() bool { return client.Ping() == nil }, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestSocketPath(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	assert.Equal(t, "/run/user/1000/pomodux/pomodux.sock", SocketPath())
+}
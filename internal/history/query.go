@@ -0,0 +1,170 @@
+// Package history queries recorded timer sessions: it filters them by time
+// range, session type, task and tags, sorts them, and splits the result into
+// pages.
+package history
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rsmacapinlac/pomodux/internal/timer"
+)
+
+// SortField is what sessions are sorted by.
+type SortField string
+
+const (
+	// SortStart orders sessions by start time, most recent first
+	SortStart SortField = "start"
+	// SortDuration orders sessions by time actually spent, longest first
+	SortDuration SortField = "duration"
+	// SortType orders sessions by session type, alphabetically
+	SortType SortField = "type"
+	// SortTask orders sessions by task, alphabetically
+	SortTask SortField = "task"
+)
+
+// SortFields lists every field sessions can be sorted by.
+var SortFields = []SortField{SortStart, SortDuration, SortType, SortTask}
+
+// ParseSortField returns the sort field with the given name.
+func ParseSortField(name string) (SortField, error) {
+	for _, field := range SortFields {
+		if string(field) == name {
+			return field, nil
+		}
+	}
+	names := make([]string, len(SortFields))
+	for i, field := range SortFields {
+		names[i] = string(field)
+	}
+	return "", fmt.Errorf("unknown sort field %q (use %s)", name, strings.Join(names, ", "))
+}
+
+// Query selects, orders and pages sessions. The zero Query matches every
+// session, most recent first, on a single page.
+type Query struct {
+	// From and To bound the start time of sessions: From is inclusive and To
+	// exclusive. A zero bound is open.
+	From time.Time
+	To   time.Time
+	// Type keeps only sessions of one type
+	Type timer.SessionType
+	// Task keeps sessions whose task contains it, ignoring case
+	Task string
+	// Tags keeps sessions that carry every one of them
+	Tags []string
+
+	Sort SortField
+	// Reverse flips the order of Sort
+	Reverse bool
+
+	// Page is the page to return, counting from 1, of Limit sessions each.
+	// A Limit of zero puts every session on one page.
+	Page  int
+	Limit int
+}
+
+// Result is the page of sessions a query selected.
+type Result struct {
+	Sessions []timer.SessionRecord
+	// Total is the number of sessions matched across every page
+	Total int
+	// Page and Pages are the page returned and the number of pages
+	Page  int
+	Pages int
+}
+
+// Matches reports whether session passes the query's filters.
+func (q Query) Matches(session timer.SessionRecord) bool {
+	if !q.From.IsZero() && session.StartTime.Before(q.From) {
+		return false
+	}
+	if !q.To.IsZero() && !session.StartTime.Before(q.To) {
+		return false
+	}
+	if q.Type != "" && session.Type != q.Type {
+		return false
+	}
+	if q.Task != "" && !strings.Contains(strings.ToLower(session.Task), strings.ToLower(q.Task)) {
+		return false
+	}
+	for _, tag := range q.Tags {
+		if !session.HasTag(strings.TrimSpace(tag)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the sessions that match the query, in the query's order.
+func (q Query) Filter(sessions []timer.SessionRecord) []timer.SessionRecord {
+	matched := make([]timer.SessionRecord, 0, len(sessions))
+	for _, session := range sessions {
+		if q.Matches(session) {
+			matched = append(matched, session)
+		}
+	}
+	q.sort(matched)
+	return matched
+}
+
+// Apply filters and sorts sessions and returns the query's page.
+func (q Query) Apply(sessions []timer.SessionRecord) Result {
+	matched := q.Filter(sessions)
+	result := Result{Total: len(matched), Page: 1, Pages: 1}
+	if q.Limit <= 0 || len(matched) == 0 {
+		result.Sessions = matched
+		return result
+	}
+
+	result.Pages = (len(matched) + q.Limit - 1) / q.Limit
+	if q.Page > 1 {
+		result.Page = q.Page
+	}
+	start := (result.Page - 1) * q.Limit
+	if start >= len(matched) {
+		result.Sessions = []timer.SessionRecord{}
+		return result
+	}
+	end := start + q.Limit
+	if end > len(matched) {
+		end = len(matched)
+	}
+	result.Sessions = matched[start:end]
+	return result
+}
+
+// Run queries every session in store.
+func Run(store timer.HistoryStore, q Query) (Result, error) {
+	sessions, err := store.GetAllSessions()
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to load session history: %w", err)
+	}
+	return q.Apply(sessions), nil
+}
+
+// sort orders sessions by the query's sort field. Ties keep the order the
+// sessions were given in.
+func (q Query) sort(sessions []timer.SessionRecord) {
+	less := func(a, b timer.SessionRecord) bool {
+		switch q.Sort {
+		case SortDuration:
+			return a.ActiveDuration() > b.ActiveDuration()
+		case SortType:
+			return a.Type < b.Type
+		case SortTask:
+			return strings.ToLower(a.Task) < strings.ToLower(b.Task)
+		default:
+			return a.StartTime.After(b.StartTime)
+		}
+	}
+	sort.SliceStable(sessions, func(i, j int) bool {
+		if q.Reverse {
+			return less(sessions[j], sessions[i])
+		}
+		return less(sessions[i], sessions[j])
+	})
+}
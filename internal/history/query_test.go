@@ -0,0 +1,102 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rsmacapinlac/pomodux/internal/timer"
+)
+
+// testSessions returns one session a day for a week of January 2025, most
+// recent first as the history store returns them.
+func testSessions() []timer.SessionRecord {
+	var sessions []timer.SessionRecord
+	for day := 12; day >= 6; day-- {
+		start := time.Date(2025, 1, day, 9, 0, 0, 0, time.UTC)
+		session := timer.SessionRecord{
+			Type:      timer.SessionTypeWork,
+			StartTime: start,
+			EndTime:   start.Add(time.Duration(day) * time.Minute),
+			Completed: true,
+		}
+		if day%2 == 0 {
+			session.Type = timer.SessionTypeBreak
+			session.Annotation = timer.Annotation{Task: "Inbox", Tags: []string{"admin"}}
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions
+}
+
+func TestQueryFilters(t *testing.T) {
+	sessions := testSessions()
+
+	q := Query{From: time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC), To: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)}
+	if result := q.Apply(sessions); result.Total != 3 || result.Sessions[0].StartTime.Day() != 9 || result.Sessions[2].StartTime.Day() != 7 {
+		t.Errorf("expected the 7th to the 9th, most recent first, got %+v", result.Sessions)
+	}
+
+	q = Query{Type: timer.SessionTypeBreak, Task: "inbox", Tags: []string{"ADMIN"}}
+	if result := q.Apply(sessions); result.Total != 4 {
+		t.Errorf("expected 4 admin breaks, got %d", result.Total)
+	}
+	if result := (Query{Tags: []string{"admin", "other"}}).Apply(sessions); result.Total != 0 {
+		t.Errorf("expected sessions to need every tag, got %d", result.Total)
+	}
+}
+
+func TestQuerySortAndPages(t *testing.T) {
+	sessions := testSessions()
+
+	result := Query{Sort: SortDuration, Limit: 3, Page: 2}.Apply(sessions)
+	if result.Total != 7 || result.Pages != 3 || result.Page != 2 || len(result.Sessions) != 3 {
+		t.Fatalf("expected page 2 of 3 with 3 sessions, got %+v", result)
+	}
+	if day := result.Sessions[0].StartTime.Day(); day != 9 {
+		t.Errorf("expected the fourth longest session first on page 2, got the %dth", day)
+	}
+
+	result = Query{Reverse: true, Limit: 3, Page: 3}.Apply(sessions)
+	if len(result.Sessions) != 1 || result.Sessions[0].StartTime.Day() != 12 {
+		t.Errorf("expected the most recent session alone on the last page, oldest first, got %+v", result.Sessions)
+	}
+	if result := (Query{Limit: 3, Page: 9}).Apply(sessions); len(result.Sessions) != 0 || result.Total != 7 {
+		t.Errorf("expected an empty page past the end, got %+v", result)
+	}
+
+	if _, err := ParseSortField("colour"); err == nil {
+		t.Errorf("expected an unknown sort field to be rejected")
+	}
+}
+
+// memoryStore is a HistoryStore holding sessions most recent first.
+type memoryStore []timer.SessionRecord
+
+func (m *memoryStore) AddSession(session timer.SessionRecord) error {
+	*m = append([]timer.SessionRecord{session}, *m...)
+	return nil
+}
+
+func (m *memoryStore) GetLastSession() (*timer.SessionRecord, error) {
+	return &(*m)[0], nil
+}
+
+func (m *memoryStore) GetRecentSessions(count int) ([]timer.SessionRecord, error) {
+	return (*m)[:count], nil
+}
+
+func (m *memoryStore) GetAllSessions() ([]timer.SessionRecord, error) {
+	return append([]timer.SessionRecord(nil), *m...), nil
+}
+
+func TestRun(t *testing.T) {
+	store := memoryStore(testSessions())
+
+	result, err := Run(&store, Query{Type: timer.SessionTypeWork})
+	if err != nil {
+		t.Fatalf("failed to run query: %v", err)
+	}
+	if result.Total != 3 || result.Sessions[0].StartTime.Day() != 11 {
+		t.Errorf("expected 3 work sessions, most recent first, got %+v", result.Sessions)
+	}
+}
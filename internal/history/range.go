@@ -0,0 +1,99 @@
+package history
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Range names accepted by NamedRange.
+const (
+	RangeToday     = "today"
+	RangeYesterday = "yesterday"
+	RangeThisWeek  = "this-week"
+	RangeLastWeek  = "last-week"
+	RangeThisMonth = "this-month"
+	RangeLastMonth = "last-month"
+)
+
+// RangeNames lists every named range.
+var RangeNames = []string{RangeToday, RangeYesterday, RangeThisWeek, RangeLastWeek, RangeThisMonth, RangeLastMonth}
+
+// dateLayouts are the layouts ParseTime accepts, most specific first.
+var dateLayouts = []string{time.RFC3339, "2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02"}
+
+// ParseTime parses a point in time given as a date (2025-01-31), a date and
+// time (2025-01-31 14:30), a time today (14:30), RFC 3339, "today" or
+// "yesterday", in now's location. It also reports whether only a date was
+// given, so callers can treat the bound as covering the whole day.
+func ParseTime(value string, now time.Time) (time.Time, bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case RangeToday:
+		return startOfDay(now), true, nil
+	case RangeYesterday:
+		return startOfDay(now).AddDate(0, 0, -1), true, nil
+	}
+
+	if clock, err := time.Parse("15:04", strings.TrimSpace(value)); err == nil {
+		return time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, now.Location()), false, nil
+	}
+	for _, layout := range dateLayouts {
+		t, err := time.ParseInLocation(layout, strings.TrimSpace(value), now.Location())
+		if err == nil {
+			return t, layout == "2006-01-02", nil
+		}
+	}
+	return time.Time{}, false, fmt.Errorf("invalid time %q (use YYYY-MM-DD, \"YYYY-MM-DD HH:MM\", HH:MM, today or yesterday)", value)
+}
+
+// ParseSince returns the time an age such as 7d, 2w, 12h or 90m before now.
+// Days and weeks are calendar days, so 1d is the same time yesterday.
+func ParseSince(age string, now time.Time) (time.Time, error) {
+	age = strings.TrimSpace(age)
+	for suffix, days := range map[string]int{"d": 1, "w": 7} {
+		if !strings.HasSuffix(age, suffix) {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(age, suffix))
+		if err != nil || n < 0 {
+			return time.Time{}, fmt.Errorf("invalid age %q", age)
+		}
+		return now.AddDate(0, 0, -n*days), nil
+	}
+
+	d, err := time.ParseDuration(age)
+	if err != nil || d < 0 {
+		return time.Time{}, fmt.Errorf("invalid age %q (use e.g. 7d, 2w, 12h or 90m)", age)
+	}
+	return now.Add(-d), nil
+}
+
+// NamedRange returns the start and the exclusive end of a named range
+// around now. Weeks start on Monday.
+func NamedRange(name string, now time.Time) (time.Time, time.Time, error) {
+	today := startOfDay(now)
+	week := today.AddDate(0, 0, -((int(now.Weekday()) + 6) % 7))
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	switch name {
+	case RangeToday:
+		return today, today.AddDate(0, 0, 1), nil
+	case RangeYesterday:
+		return today.AddDate(0, 0, -1), today, nil
+	case RangeThisWeek:
+		return week, week.AddDate(0, 0, 7), nil
+	case RangeLastWeek:
+		return week.AddDate(0, 0, -7), week, nil
+	case RangeThisMonth:
+		return month, month.AddDate(0, 1, 0), nil
+	case RangeLastMonth:
+		return month.AddDate(0, -1, 0), month, nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("unknown range %q (use %s)", name, strings.Join(RangeNames, ", "))
+}
+
+// startOfDay returns midnight at the start of t's day.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
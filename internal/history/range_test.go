@@ -0,0 +1,71 @@
+package history
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2025, 1, 8, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value    string
+		expected time.Time
+		dateOnly bool
+	}{
+		{"2025-01-31", time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC), true},
+		{"2025-01-31 14:30", time.Date(2025, 1, 31, 14, 30, 0, 0, time.UTC), false},
+		{"2025-01-31T14:30:00Z", time.Date(2025, 1, 31, 14, 30, 0, 0, time.UTC), false},
+		{"09:15", time.Date(2025, 1, 8, 9, 15, 0, 0, time.UTC), false},
+		{"yesterday", time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC), true},
+	}
+	for _, tt := range tests {
+		got, dateOnly, err := ParseTime(tt.value, now)
+		if err != nil || !got.Equal(tt.expected) || dateOnly != tt.dateOnly {
+			t.Errorf("ParseTime(%q) = %v, %t, %v; expected %v, %t", tt.value, got, dateOnly, err, tt.expected, tt.dateOnly)
+		}
+	}
+	if _, _, err := ParseTime("31/01/2025", now); err == nil {
+		t.Errorf("expected an unknown date format to be rejected")
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2025, 1, 8, 15, 0, 0, 0, time.UTC)
+	for age, expected := range map[string]time.Time{
+		"7d":  time.Date(2025, 1, 1, 15, 0, 0, 0, time.UTC),
+		"2w":  time.Date(2024, 12, 25, 15, 0, 0, 0, time.UTC),
+		"90m": time.Date(2025, 1, 8, 13, 30, 0, 0, time.UTC),
+	} {
+		if got, err := ParseSince(age, now); err != nil || !got.Equal(expected) {
+			t.Errorf("ParseSince(%q) = %v, %v; expected %v", age, got, err, expected)
+		}
+	}
+	for _, age := range []string{"", "d", "-3d", "seven days"} {
+		if _, err := ParseSince(age, now); err == nil {
+			t.Errorf("expected ParseSince(%q) to fail", age)
+		}
+	}
+}
+
+func TestNamedRange(t *testing.T) {
+	// Wednesday
+	now := time.Date(2025, 1, 8, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		from, to time.Time
+	}{
+		{RangeToday, time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC)},
+		{RangeThisWeek, time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)},
+		{RangeLastWeek, time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)},
+		{RangeLastMonth, time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		from, to, err := NamedRange(tt.name, now)
+		if err != nil || !from.Equal(tt.from) || !to.Equal(tt.to) {
+			t.Errorf("NamedRange(%q) = %v to %v, %v; expected %v to %v", tt.name, from, to, err, tt.from, tt.to)
+		}
+	}
+	if _, _, err := NamedRange("next-year", now); err == nil {
+		t.Errorf("expected an unknown range to be rejected")
+	}
+}
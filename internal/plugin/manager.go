@@ -23,6 +23,13 @@ const (
 	EventTimerResumed   EventType = "timer_resumed"
 	EventTimerCompleted EventType = "timer_completed"
 	EventTimerStopped   EventType = "timer_stopped"
+	EventTimerSkipped   EventType = "timer_skipped"
+	EventTimerCancelled EventType = "timer_cancelled"
+	EventTimerRecovered EventType = "timer_recovered"
+	EventTimerExtended  EventType = "timer_extended"
+	EventGoalReached    EventType = "goal_reached"
+	EventStreakExtended EventType = "streak_extended"
+	EventStreakBroken   EventType = "streak_broken"
 )
 
 // Event represents a timer event
@@ -46,12 +53,14 @@ type Plugin struct {
 
 // PluginManager manages the plugin system
 type PluginManager struct {
-	plugins    map[string]*Plugin
-	events     chan Event
-	mu         sync.RWMutex
-	done       chan struct{}
-	pluginsDir string
-	api        *PluginAPI
+	plugins      map[string]*Plugin
+	events       chan Event
+	mu           sync.RWMutex
+	done         chan struct{}
+	stopped      chan struct{}
+	shutdownOnce sync.Once
+	pluginsDir   string
+	api          *PluginAPI
 }
 
 // PluginAPI provides the interface for plugins to register themselves
@@ -65,6 +74,7 @@ func NewPluginManager(pluginsDir string) *PluginManager {
 		plugins:    make(map[string]*Plugin),
 		events:     make(chan Event, 100),
 		done:       make(chan struct{}),
+		stopped:    make(chan struct{}),
 		pluginsDir: pluginsDir,
 	}
 
@@ -307,11 +317,25 @@ func (pm *PluginManager) EmitEvent(event Event) {
 
 // processEvents processes events and calls plugin hooks
 func (pm *PluginManager) processEvents() {
+	defer close(pm.stopped)
 	for {
 		select {
 		case event := <-pm.events:
 			pm.callPluginHooks(event)
 		case <-pm.done:
+			pm.drainEvents()
+			return
+		}
+	}
+}
+
+// drainEvents delivers events that were queued before shutdown was requested
+func (pm *PluginManager) drainEvents() {
+	for {
+		select {
+		case event := <-pm.events:
+			pm.callPluginHooks(event)
+		default:
 			return
 		}
 	}
@@ -367,6 +391,12 @@ func (pm *PluginManager) callHook(plugin *Plugin, hook lua.LValue, event Event)
 			dataTable.RawSetString(k, lua.LNumber(val))
 		case bool:
 			dataTable.RawSetString(k, lua.LBool(val))
+		case []string:
+			list := L.CreateTable(len(val), 0)
+			for _, item := range val {
+				list.Append(lua.LString(item))
+			}
+			dataTable.RawSetString(k, list)
 		default:
 			dataTable.RawSetString(k, lua.LString(fmt.Sprintf("%v", val)))
 		}
@@ -441,18 +471,23 @@ func (pm *PluginManager) UnloadPlugin(name string) error {
 	return nil
 }
 
-// Shutdown shuts down the plugin manager
+// Shutdown shuts down the plugin manager. Events that were already queued
+// are delivered to their hooks before the plugins are unloaded. It is safe to
+// call Shutdown more than once.
 func (pm *PluginManager) Shutdown() {
-	close(pm.done)
+	pm.shutdownOnce.Do(func() {
+		close(pm.done)
+		<-pm.stopped
 
-	// Unload all plugins
-	pm.mu.Lock()
-	defer pm.mu.Unlock()
+		// Unload all plugins
+		pm.mu.Lock()
+		defer pm.mu.Unlock()
 
-	for name, plugin := range pm.plugins {
-		plugin.LState.Close()
-		delete(pm.plugins, name)
-	}
+		for name, plugin := range pm.plugins {
+			plugin.LState.Close()
+			delete(pm.plugins, name)
+		}
+	})
 }
 
 // validateFilePath validates a file path for security
@@ -3,6 +3,7 @@ package plugin
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -306,3 +307,72 @@ pomodux.register_plugin({
 	_, exists = pm.GetPlugin("shutdown_test")
 	assert.False(t, exists)
 }
+
+func TestPluginSystem_ShutdownDrainsQueuedEvents(t *testing.T) {
+	pluginsDir := t.TempDir()
+	pm := NewPluginManager(pluginsDir)
+
+	outFile := filepath.Join(t.TempDir(), "events.txt")
+	pluginCode := `
+pomodux.register_plugin({
+    name = "drain_test",
+    version = "1.0.0",
+    description = "Records events to a file",
+    author = "Test Author"
+})
+
+pomodux.register_hook("timer_completed", function(event)
+    local f = io.open("` + outFile + `", "a")
+    f:write(event.type .. "\n")
+    f:close()
+end)
+`
+	require.NoError(t, pm.LoadPlugin("drain_test", pluginCode))
+
+	for i := 0; i < 5; i++ {
+		pm.EmitEvent(Event{Type: EventTimerCompleted, Timestamp: time.Now()})
+	}
+
+	// Shutdown must not return before the queued events reach the hook
+	pm.Shutdown()
+	pm.Shutdown() // second call is a no-op
+
+	data, err := os.ReadFile(outFile)
+	require.NoError(t, err)
+	assert.Equal(t, 5, strings.Count(string(data), "timer_completed"))
+}
+
+func TestPluginSystem_ListEventDataBecomesLuaTable(t *testing.T) {
+	pm := NewPluginManager(t.TempDir())
+
+	outFile := filepath.Join(t.TempDir(), "tags.txt")
+	pluginCode := `
+pomodux.register_plugin({
+    name = "tags_test",
+    version = "1.0.0",
+    description = "Records tags to a file",
+    author = "Test Author"
+})
+
+pomodux.register_hook("timer_started", function(event)
+    local f = io.open("` + outFile + `", "a")
+    f:write(event.data.task .. ":" .. #event.data.tags .. ":" .. table.concat(event.data.tags, ","))
+    f:close()
+end)
+`
+	require.NoError(t, pm.LoadPlugin("tags_test", pluginCode))
+
+	pm.EmitEvent(Event{
+		Type:      EventTimerStarted,
+		Timestamp: time.Now(),
+		Data: map[string]interface{}{
+			"task": "Review PR 42",
+			"tags": []string{"backend", "review"},
+		},
+	})
+	pm.Shutdown()
+
+	data, err := os.ReadFile(outFile)
+	require.NoError(t, err)
+	assert.Equal(t, "Review PR 42:2:backend,review", string(data))
+}
@@ -0,0 +1,95 @@
+package theme
+
+import "sort"
+
+// builtins are the themes that ship with Pomodux.
+var builtins = map[string]Theme{
+	"default": {
+		Colors: map[string]string{
+			"work":       "red",
+			"break":      "green",
+			"long-break": "blue",
+			RolePaused:   "yellow",
+			RoleSuccess:  "green",
+			RoleFailure:  "red",
+			RoleMuted:    "gray",
+		},
+		Bar: Bar{Left: "[", Filled: "█", Empty: "░", Right: "]"},
+		Icons: map[string]string{
+			"work":         "🍅",
+			"break":        "☕",
+			"long-break":   "🌴",
+			IconPaused:     "⏸️",
+			IconResumed:    "▶️",
+			IconStopwatch:  "⏱️",
+			IconOvertime:   "⏰",
+			IconCompleted:  "✓",
+			IconIncomplete: "✗",
+		},
+	},
+	// ascii only uses plain ASCII, for terminals and fonts without the glyphs
+	"ascii": {
+		Colors: map[string]string{
+			"work":       "red",
+			"break":      "green",
+			"long-break": "blue",
+			RolePaused:   "yellow",
+			RoleSuccess:  "green",
+			RoleFailure:  "red",
+		},
+		Bar: Bar{Left: "[", Filled: "#", Empty: "-", Right: "]"},
+		Icons: map[string]string{
+			IconCompleted:  "+",
+			IconIncomplete: "x",
+		},
+	},
+	// minimal has a thin bar, no icons and only the session colors
+	"minimal": {
+		Colors: map[string]string{
+			"work":       "bright-red",
+			"break":      "bright-green",
+			"long-break": "bright-blue",
+			RoleMuted:    "gray",
+		},
+		Bar:   Bar{Filled: "━", Empty: "─"},
+		Icons: map[string]string{},
+	},
+}
+
+// Builtin returns a copy of the named built-in theme, with color disabled.
+func Builtin(name string) (*Theme, bool) {
+	builtin, ok := builtins[name]
+	if !ok {
+		return nil, false
+	}
+
+	t := &Theme{
+		Name:   name,
+		Colors: make(map[string]string, len(builtin.Colors)),
+		Bar:    builtin.Bar,
+		Icons:  make(map[string]string, len(builtin.Icons)),
+	}
+	for role, color := range builtin.Colors {
+		t.Colors[role] = color
+	}
+	for name, icon := range builtin.Icons {
+		t.Icons[name] = icon
+	}
+	return t, true
+}
+
+// BuiltinNames returns the names of the built-in themes.
+func BuiltinNames() []string {
+	names := make([]string, 0, len(builtins))
+	for name := range builtins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Default returns the default theme, with color disabled.
+func Default() *Theme {
+	t, _ := Builtin("default")
+	return t
+}
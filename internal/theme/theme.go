@@ -0,0 +1,290 @@
+// Package theme controls how Pomodux output looks: the colors used for each
+// session type, the glyphs of the progress bar, and the icons shown next to
+// sessions. Themes are either built in or loaded from YAML files in the
+// themes directory of the configuration.
+package theme
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
+)
+
+// Color roles. Session types are roles too, named after the session type.
+const (
+	RolePaused  = "paused"
+	RoleSuccess = "success"
+	RoleFailure = "failure"
+	RoleMuted   = "muted"
+)
+
+// Icon names. Session types are icon names too.
+const (
+	IconPaused     = "paused"
+	IconResumed    = "resumed"
+	IconStopwatch  = "stopwatch"
+	IconOvertime   = "overtime"
+	IconCompleted  = "completed"
+	IconIncomplete = "incomplete"
+)
+
+// Bar is the glyphs a progress bar is drawn with.
+type Bar struct {
+	Left   string `yaml:"left"`
+	Filled string `yaml:"filled"`
+	Empty  string `yaml:"empty"`
+	Right  string `yaml:"right"`
+}
+
+// Theme is a set of colors, progress bar glyphs and icons.
+type Theme struct {
+	Name string `yaml:"name"`
+	// Extends names the built-in theme a theme file starts from
+	Extends string `yaml:"extends,omitempty"`
+	// Colors maps a role to a color name, an ANSI color number or #rrggbb,
+	// optionally prefixed with "bold"
+	Colors map[string]string `yaml:"colors"`
+	Bar    Bar               `yaml:"bar"`
+	Icons  map[string]string `yaml:"icons"`
+
+	// codes holds the escape sequence for each role; it is empty when color is disabled
+	codes map[string]string
+}
+
+// Load returns the named theme, looking in the themes directory first and
+// then at the built-in themes. Color is enabled unless NO_COLOR is set or
+// standard output is not a terminal.
+func Load(name string) (*Theme, error) {
+	if name == "" {
+		name = "default"
+	}
+
+	t, err := loadFile(filepath.Join(Dir(), name+".yaml"))
+	if os.IsNotExist(err) {
+		builtin, ok := Builtin(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown theme %q (built-in themes: %s)", name, strings.Join(BuiltinNames(), ", "))
+		}
+		t, err = builtin, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if ColorEnabled() {
+		if err := t.EnableColor(); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// loadFile reads a theme file. Anything the file leaves out comes from the
+// built-in theme it extends, or from the default theme.
+func loadFile(path string) (*Theme, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is within the user's config directory
+	if err != nil {
+		return nil, err
+	}
+
+	var file Theme
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse theme %s: %w", path, err)
+	}
+
+	baseName := file.Extends
+	if baseName == "" {
+		baseName = "default"
+	}
+	t, ok := Builtin(baseName)
+	if !ok {
+		return nil, fmt.Errorf("theme %s extends unknown theme %q", path, baseName)
+	}
+
+	t.Name = strings.TrimSuffix(filepath.Base(path), ".yaml")
+	if file.Name != "" {
+		t.Name = file.Name
+	}
+	for role, color := range file.Colors {
+		t.Colors[role] = color
+	}
+	for name, icon := range file.Icons {
+		t.Icons[name] = icon
+	}
+	t.Bar = Bar{
+		Left:   fallback(file.Bar.Left, t.Bar.Left),
+		Filled: fallback(file.Bar.Filled, t.Bar.Filled),
+		Empty:  fallback(file.Bar.Empty, t.Bar.Empty),
+		Right:  fallback(file.Bar.Right, t.Bar.Right),
+	}
+
+	if _, err := t.escapeCodes(); err != nil {
+		return nil, fmt.Errorf("invalid theme %s: %w", path, err)
+	}
+	return t, nil
+}
+
+// fallback returns value, or base if value is empty.
+func fallback(value, base string) string {
+	if value == "" {
+		return base
+	}
+	return value
+}
+
+// Dir returns the directory user themes are loaded from
+// (XDG_CONFIG_HOME/pomodux/themes).
+func Dir() string {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "themes"
+		}
+		configHome = filepath.Join(homeDir, ".config")
+	}
+	return filepath.Join(configHome, "pomodux", "themes")
+}
+
+// ColorEnabled reports whether output should be colored: NO_COLOR must not
+// be set and standard output must be a terminal.
+func ColorEnabled() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// EnableColor turns on colored output for the theme.
+func (t *Theme) EnableColor() error {
+	codes, err := t.escapeCodes()
+	if err != nil {
+		return err
+	}
+	t.codes = codes
+	return nil
+}
+
+// escapeCodes converts the theme's colors to escape sequences.
+func (t *Theme) escapeCodes() (map[string]string, error) {
+	codes := make(map[string]string, len(t.Colors))
+	for role, color := range t.Colors {
+		code, err := parseColor(color)
+		if err != nil {
+			return nil, fmt.Errorf("color for %s: %w", role, err)
+		}
+		codes[role] = code
+	}
+	return codes, nil
+}
+
+// SetColor sets the color of role, such as the color of a user-defined
+// session type.
+func (t *Theme) SetColor(role, color string) error {
+	code, err := parseColor(color)
+	if err != nil {
+		return err
+	}
+	if t.Colors == nil {
+		t.Colors = make(map[string]string)
+	}
+	t.Colors[role] = color
+	if t.codes != nil {
+		t.codes[role] = code
+	}
+	return nil
+}
+
+// ValidateColor checks that color is a color name, an ANSI color number or
+// #rrggbb, optionally prefixed with "bold".
+func ValidateColor(color string) error {
+	_, err := parseColor(color)
+	return err
+}
+
+// Paint colors s with the color of role. It returns s unchanged when color
+// is disabled or the theme has no color for role.
+func (t *Theme) Paint(role, s string) string {
+	code := t.codes[role]
+	if code == "" || s == "" {
+		return s
+	}
+	return code + s + "\x1b[0m"
+}
+
+// Icon returns the named icon followed by a space, or "" if the theme has none.
+func (t *Theme) Icon(name string) string {
+	if icon := t.Icons[name]; icon != "" {
+		return icon + " "
+	}
+	return ""
+}
+
+// ProgressBar draws progress between 0 and 1 as a bar width glyphs wide,
+// with the filled part in the color of role.
+func (t *Theme) ProgressBar(progress float64, width int, role string) string {
+	if progress < 0 {
+		progress = 0
+	}
+	if progress > 1 {
+		progress = 1
+	}
+
+	filled := int(float64(width) * progress)
+	return t.Bar.Left +
+		t.Paint(role, strings.Repeat(t.Bar.Filled, filled)) +
+		t.Paint(RoleMuted, strings.Repeat(t.Bar.Empty, width-filled)) +
+		t.Bar.Right
+}
+
+// namedColors maps color names to their ANSI foreground codes.
+var namedColors = map[string]int{
+	"black": 30, "red": 31, "green": 32, "yellow": 33,
+	"blue": 34, "magenta": 35, "cyan": 36, "white": 37,
+	"gray": 90, "grey": 90, "bright-red": 91, "bright-green": 92, "bright-yellow": 93,
+	"bright-blue": 94, "bright-magenta": 95, "bright-cyan": 96, "bright-white": 97,
+}
+
+// parseColor converts a color name, an ANSI color number (0-255) or #rrggbb,
+// optionally prefixed with "bold", to an escape sequence. An empty color or
+// "none" means no color.
+func parseColor(color string) (string, error) {
+	fields := strings.Fields(strings.ToLower(color))
+	var params []string
+	if len(fields) > 0 && fields[0] == "bold" {
+		params = append(params, "1")
+		fields = fields[1:]
+	}
+
+	switch {
+	case len(fields) == 0 || fields[0] == "none":
+	case len(fields) > 1:
+		return "", fmt.Errorf("invalid color %q", color)
+	case strings.HasPrefix(fields[0], "#"):
+		rgb, err := strconv.ParseUint(strings.TrimPrefix(fields[0], "#"), 16, 32)
+		if err != nil || len(fields[0]) != 7 {
+			return "", fmt.Errorf("invalid color %q (expected #rrggbb)", color)
+		}
+		params = append(params, fmt.Sprintf("38;2;%d;%d;%d", rgb>>16, rgb>>8&0xff, rgb&0xff))
+	default:
+		if code, ok := namedColors[fields[0]]; ok {
+			params = append(params, strconv.Itoa(code))
+			break
+		}
+		n, err := strconv.Atoi(fields[0])
+		if err != nil || n < 0 || n > 255 {
+			return "", fmt.Errorf("unknown color %q", color)
+		}
+		params = append(params, fmt.Sprintf("38;5;%d", n))
+	}
+
+	if len(params) == 0 {
+		return "", nil
+	}
+	return "\x1b[" + strings.Join(params, ";") + "m", nil
+}
@@ -0,0 +1,146 @@
+package theme
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProgressBarGeneration(t *testing.T) {
+	theme := Default()
+
+	tests := []struct {
+		name     string
+		progress float64
+		expected string
+	}{
+		{"ProgressBarZero", 0.0, "[░░░░░░░░░░]"},
+		{"ProgressBarHalf", 0.5, "[█████░░░░░]"},
+		{"ProgressBarFull", 1.0, "[██████████]"},
+		{"ProgressBarNegative", -0.5, "[░░░░░░░░░░]"},
+		{"ProgressBarOverflow", 1.5, "[██████████]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if bar := theme.ProgressBar(tt.progress, 10, "work"); bar != tt.expected {
+				t.Errorf("expected progress bar %s, got %s", tt.expected, bar)
+			}
+		})
+	}
+}
+
+func TestBuiltinThemesHaveValidColors(t *testing.T) {
+	for _, name := range BuiltinNames() {
+		theme, ok := Builtin(name)
+		if !ok {
+			t.Fatalf("expected built-in theme %s", name)
+		}
+		if err := theme.EnableColor(); err != nil {
+			t.Errorf("built-in theme %s has an invalid color: %v", name, err)
+		}
+	}
+}
+
+func TestPaintUsesRoleColor(t *testing.T) {
+	theme := Default()
+	if painted := theme.Paint("work", "work"); painted != "work" {
+		t.Errorf("expected no color before color is enabled, got %q", painted)
+	}
+
+	if err := theme.EnableColor(); err != nil {
+		t.Fatalf("failed to enable color: %v", err)
+	}
+	if painted := theme.Paint("work", "work"); painted != "\x1b[31mwork\x1b[0m" {
+		t.Errorf("expected work to be painted red, got %q", painted)
+	}
+	if painted := theme.Paint("unknown", "text"); painted != "text" {
+		t.Errorf("expected a role without a color to be left alone, got %q", painted)
+	}
+}
+
+func TestParseColor(t *testing.T) {
+	tests := map[string]string{
+		"red":          "\x1b[31m",
+		"bold blue":    "\x1b[1;34m",
+		"208":          "\x1b[38;5;208m",
+		"#ff8000":      "\x1b[38;2;255;128;0m",
+		"none":         "",
+		"":             "",
+		"bright-green": "\x1b[92m",
+	}
+	for color, want := range tests {
+		got, err := parseColor(color)
+		if err != nil {
+			t.Errorf("parseColor(%q) failed: %v", color, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("parseColor(%q) = %q, want %q", color, got, want)
+		}
+	}
+
+	for _, color := range []string{"reddish", "256", "#12345", "#gggggg", "bold red blue"} {
+		if _, err := parseColor(color); err == nil {
+			t.Errorf("expected parseColor(%q) to fail", color)
+		}
+	}
+}
+
+func TestLoadUserTheme(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	t.Setenv("NO_COLOR", "1")
+
+	dir := filepath.Join(configHome, "pomodux", "themes")
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		t.Fatalf("failed to create themes directory: %v", err)
+	}
+	data := `extends: ascii
+colors:
+  work: "#ff8000"
+bar:
+  filled: "="
+icons:
+  work: "W"
+`
+	if err := os.WriteFile(filepath.Join(dir, "sunset.yaml"), []byte(data), 0600); err != nil {
+		t.Fatalf("failed to write theme: %v", err)
+	}
+
+	theme, err := Load("sunset")
+	if err != nil {
+		t.Fatalf("failed to load theme: %v", err)
+	}
+	if theme.Name != "sunset" || theme.Colors["work"] != "#ff8000" || theme.Icon("work") != "W " {
+		t.Errorf("expected the theme file to be applied, got %+v", theme)
+	}
+	// Everything else comes from the theme it extends
+	if bar := theme.ProgressBar(0.5, 4, "work"); bar != "[==--]" {
+		t.Errorf("expected the ascii bar with the theme's fill, got %q", bar)
+	}
+	// NO_COLOR disables color whatever the theme says
+	if painted := theme.Paint("work", "work"); strings.Contains(painted, "\x1b") {
+		t.Errorf("expected NO_COLOR to disable color, got %q", painted)
+	}
+}
+
+func TestLoadRejectsUnknownAndInvalidThemes(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+
+	if _, err := Load("does-not-exist"); err == nil {
+		t.Errorf("expected an unknown theme to fail to load")
+	}
+
+	dir := filepath.Join(configHome, "pomodux", "themes")
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		t.Fatalf("failed to create themes directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "broken.yaml"), []byte("colors:\n  work: reddish\n"), 0600); err != nil {
+		t.Fatalf("failed to write theme: %v", err)
+	}
+	if _, err := Load("broken"); err == nil {
+		t.Errorf("expected a theme with an invalid color to fail to load")
+	}
+}
@@ -0,0 +1,33 @@
+package timer
+
+import "strings"
+
+// Annotation describes what a session was spent on.
+type Annotation struct {
+	Task string   `json:"task,omitempty"`
+	Tags []string `json:"tags,omitempty"`
+}
+
+// NewAnnotation creates an annotation, trimming the task and dropping empty
+// and duplicate tags. Tags are compared case-insensitively.
+func NewAnnotation(task string, tags []string) Annotation {
+	annotation := Annotation{Task: strings.TrimSpace(task)}
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || annotation.HasTag(tag) {
+			continue
+		}
+		annotation.Tags = append(annotation.Tags, tag)
+	}
+	return annotation
+}
+
+// HasTag reports whether the annotation carries tag, ignoring case.
+func (a Annotation) HasTag(tag string) bool {
+	for _, t := range a.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package timer
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewAnnotationNormalizesTags(t *testing.T) {
+	annotation := NewAnnotation("  Review PR 42 ", []string{"backend", " review", "", "Backend"})
+
+	if annotation.Task != "Review PR 42" {
+		t.Errorf("expected trimmed task, got %q", annotation.Task)
+	}
+	if want := []string{"backend", "review"}; !reflect.DeepEqual(annotation.Tags, want) {
+		t.Errorf("expected tags %v, got %v", want, annotation.Tags)
+	}
+	if !annotation.HasTag("REVIEW") {
+		t.Error("expected tags to match case-insensitively")
+	}
+}
+
+func TestTimerRecordsAnnotation(t *testing.T) {
+	timer, clock := newTestTimer(t)
+
+	annotation := NewAnnotation("Review PR 42", []string{"backend", "review"})
+	if err := timer.StartAnnotated(25*time.Minute, SessionTypeWork, annotation); err != nil {
+		t.Fatalf("failed to start timer: %v", err)
+	}
+
+	state, err := timer.stateManager.LoadState()
+	if err != nil {
+		t.Fatalf("failed to load state: %v", err)
+	}
+	if !reflect.DeepEqual(state.Annotation, annotation) {
+		t.Errorf("expected annotation in state, got %+v", state.Annotation)
+	}
+
+	clock.Advance(25 * time.Minute)
+	timer.GetStatus()
+
+	last, err := timer.historyManager.GetLastSession()
+	if err != nil {
+		t.Fatalf("failed to read history: %v", err)
+	}
+	if !reflect.DeepEqual(last.Annotation, annotation) {
+		t.Errorf("expected annotation in history, got %+v", last.Annotation)
+	}
+}
@@ -0,0 +1,67 @@
+package timer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestWriteFileAtomicReplacesContents(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+
+	for _, contents := range []string{"first", "second"} {
+		if err := writeFileAtomic(path, []byte(contents), 0600); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read file: %v", err)
+		}
+		if string(data) != contents {
+			t.Errorf("expected %q, got %q", contents, data)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to list directory: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected temporary files to be cleaned up, found %d entries", len(entries))
+	}
+}
+
+func TestAddSessionConcurrentWritersKeepEveryRecord(t *testing.T) {
+	historyFile := filepath.Join(t.TempDir(), "session_history.jsonl")
+
+	// Separate managers share no mutex, like separate pomodux processes
+	const writers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			hm := &HistoryManager{historyFile: historyFile}
+			errs <- hm.AddSession(SessionRecord{ID: fmt.Sprintf("s%d", i), Type: SessionTypeWork})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("failed to add session: %v", err)
+		}
+	}
+
+	history, err := (&HistoryManager{historyFile: historyFile}).GetRecentSessions(writers * 2)
+	if err != nil {
+		t.Fatalf("failed to read history: %v", err)
+	}
+	if len(history) != writers {
+		t.Errorf("expected %d records, got %d", writers, len(history))
+	}
+}
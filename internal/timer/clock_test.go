@@ -0,0 +1,91 @@
+package timer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClockLongSessionCompletes(t *testing.T) {
+	start := testStart
+	timer, clock := newTestTimer(t)
+
+	if err := timer.Start(2 * time.Hour); err != nil {
+		t.Fatalf("failed to start timer: %v", err)
+	}
+
+	clock.Advance(time.Hour)
+	if progress := timer.GetProgress(); progress != 0.5 {
+		t.Errorf("expected progress 0.5 after one hour, got %v", progress)
+	}
+	if status := timer.GetStatus(); status != StatusRunning {
+		t.Fatalf("expected running status, got %v", status)
+	}
+
+	clock.Advance(time.Hour)
+	if status := timer.GetStatus(); status != StatusCompleted {
+		t.Fatalf("expected completed status, got %v", status)
+	}
+
+	last, err := timer.historyManager.GetLastSession()
+	if err != nil {
+		t.Fatalf("failed to read history: %v", err)
+	}
+	if !last.StartTime.Equal(start) || !last.EndTime.Equal(start.Add(2*time.Hour)) {
+		t.Errorf("expected session from %v to %v, got %v to %v", start, start.Add(2*time.Hour), last.StartTime, last.EndTime)
+	}
+}
+
+func TestFakeClockPausedTimeIsNotCounted(t *testing.T) {
+	clock := NewFakeClock(testStart)
+	timer := NewTimerWithClock(clock)
+
+	if err := timer.Start(25 * time.Minute); err != nil {
+		t.Fatalf("failed to start timer: %v", err)
+	}
+	clock.Advance(10 * time.Minute)
+	if err := timer.Pause(); err != nil {
+		t.Fatalf("failed to pause timer: %v", err)
+	}
+
+	clock.Advance(3 * time.Hour)
+	if elapsed := timer.GetElapsed(); elapsed != 10*time.Minute {
+		t.Errorf("expected 10m elapsed while paused, got %v", elapsed)
+	}
+
+	if err := timer.Resume(); err != nil {
+		t.Fatalf("failed to resume timer: %v", err)
+	}
+	clock.Advance(14 * time.Minute)
+	if status := timer.GetStatus(); status != StatusRunning {
+		t.Fatalf("expected running status, got %v", status)
+	}
+	clock.Advance(time.Minute)
+	if status := timer.GetStatus(); status != StatusCompleted {
+		t.Errorf("expected completed status, got %v", status)
+	}
+}
+
+func TestFakeClockSessionAcrossDSTTransition(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data not available: %v", err)
+	}
+
+	// Clocks jump from 02:00 to 03:00 on this day, so a one-hour session
+	// started at 01:30 ends at 03:30 wall-clock time
+	start := time.Date(2025, 3, 9, 1, 30, 0, 0, location)
+	clock := NewFakeClock(start)
+	timer := NewTimerWithClock(clock)
+
+	if err := timer.Start(time.Hour); err != nil {
+		t.Fatalf("failed to start timer: %v", err)
+	}
+	clock.Set(time.Date(2025, 3, 9, 3, 29, 0, 0, location))
+	if status := timer.GetStatus(); status != StatusRunning {
+		t.Fatalf("expected running status, got %v", status)
+	}
+	clock.Set(time.Date(2025, 3, 9, 3, 30, 0, 0, location))
+	if status := timer.GetStatus(); status != StatusCompleted {
+		t.Errorf("expected completed status, got %v", status)
+	}
+}
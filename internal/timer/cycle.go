@@ -0,0 +1,133 @@
+package timer
+
+import (
+	"time"
+
+	"github.com/rsmacapinlac/pomodux/internal/logger"
+)
+
+// CycleConfig controls how the Pomodoro cycle advances between sessions.
+type CycleConfig struct {
+	WorkDuration      time.Duration
+	BreakDuration     time.Duration
+	LongBreakDuration time.Duration
+	// LongBreakInterval is the number of work sessions before a long break
+	LongBreakInterval int
+	AutoStartBreaks   bool
+	AutoStartWork     bool
+	// Overtime makes every session keep counting past its planned end
+	Overtime bool
+}
+
+// DefaultCycleConfig returns the classic Pomodoro cycle: four 25-minute work
+// sessions separated by 5-minute breaks, followed by a 15-minute long break.
+func DefaultCycleConfig() CycleConfig {
+	return CycleConfig{
+		WorkDuration:      25 * time.Minute,
+		BreakDuration:     5 * time.Minute,
+		LongBreakDuration: 15 * time.Minute,
+		LongBreakInterval: 4,
+	}
+}
+
+// DurationFor returns the configured duration for a session type.
+func (c CycleConfig) DurationFor(sessionType SessionType) time.Duration {
+	switch sessionType {
+	case SessionTypeBreak:
+		return c.BreakDuration
+	case SessionTypeLongBreak:
+		return c.LongBreakDuration
+	default:
+		return c.WorkDuration
+	}
+}
+
+// autoStarts reports whether a session of the given type starts by itself
+// when the previous session completes.
+func (c CycleConfig) autoStarts(sessionType SessionType) bool {
+	if sessionType == SessionTypeWork {
+		return c.AutoStartWork
+	}
+	return c.AutoStartBreaks
+}
+
+// Cycle is the persisted position in the Pomodoro cycle.
+type Cycle struct {
+	// Pomodoros is the number of work sessions completed since the last long break
+	Pomodoros int `json:"pomodoros"`
+	// LastSessionType is the type of the last completed session
+	LastSessionType SessionType `json:"last_session_type,omitempty"`
+}
+
+// Next returns the session type that follows the last completed session.
+func (c Cycle) Next(longBreakInterval int) SessionType {
+	if c.LastSessionType != SessionTypeWork {
+		return SessionTypeWork
+	}
+	if longBreakInterval > 0 && c.Pomodoros >= longBreakInterval {
+		return SessionTypeLongBreak
+	}
+	return SessionTypeBreak
+}
+
+// advance records a completed session of the given type. User-defined
+// session types do not move the cycle.
+func (c *Cycle) advance(sessionType SessionType) {
+	if !sessionType.IsBuiltin() {
+		return
+	}
+	switch sessionType {
+	case SessionTypeWork:
+		c.Pomodoros++
+	case SessionTypeLongBreak:
+		c.Pomodoros = 0
+	}
+	c.LastSessionType = sessionType
+}
+
+// SetCycleConfig sets how the timer advances through the Pomodoro cycle.
+func (t *Timer) SetCycleConfig(cycleConfig CycleConfig) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cycleConfig = cycleConfig
+}
+
+// GetCycle returns the timer's position in the Pomodoro cycle.
+func (t *Timer) GetCycle() Cycle {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.cycle
+}
+
+// StartNext starts the next session in the Pomodoro cycle with its configured
+// duration. With overtime, the session keeps counting past its planned end.
+func (t *Timer) StartNext(annotation Annotation, overtime bool) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	next := t.cycle.Next(t.cycleConfig.LongBreakInterval)
+	return t.startLocked(t.cycleConfig.DurationFor(next), next, annotation, overtime)
+}
+
+// upcomingSessionTypeLocked returns the session type that follows the current
+// session, assuming it completes. The caller must hold t.mu.
+func (t *Timer) upcomingSessionTypeLocked() SessionType {
+	cycle := t.cycle
+	if (t.status == StatusRunning || t.status == StatusPaused) && !t.planReached && !t.stopwatchLocked() {
+		cycle.advance(t.sessionType)
+	}
+	return cycle.Next(t.cycleConfig.LongBreakInterval)
+}
+
+// autoStartNextLocked starts the next session in the cycle if the cycle
+// configuration asks for it. The caller must hold t.mu.
+func (t *Timer) autoStartNextLocked() {
+	next := t.cycle.Next(t.cycleConfig.LongBreakInterval)
+	if !t.cycleConfig.autoStarts(next) {
+		return
+	}
+
+	logger.Info("Auto-starting next session", map[string]interface{}{"session_type": next})
+	if err := t.startLocked(t.cycleConfig.DurationFor(next), next, Annotation{}, false); err != nil {
+		logger.Warn("Failed to auto-start next session", map[string]interface{}{"session_type": next, "error": err.Error()})
+	}
+}
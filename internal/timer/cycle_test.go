@@ -0,0 +1,178 @@
+package timer
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCycleNext(t *testing.T) {
+	var cycle Cycle
+	expected := []SessionType{
+		SessionTypeWork, SessionTypeBreak,
+		SessionTypeWork, SessionTypeBreak,
+		SessionTypeWork, SessionTypeLongBreak,
+	}
+
+	for i, want := range expected {
+		got := cycle.Next(3)
+		if got != want {
+			t.Fatalf("step %d: expected %s, got %s", i, want, got)
+		}
+		cycle.advance(got)
+	}
+
+	if cycle.Pomodoros != 0 {
+		t.Errorf("expected long break to reset pomodoros, got %d", cycle.Pomodoros)
+	}
+	if next := cycle.Next(3); next != SessionTypeWork {
+		t.Errorf("expected work after long break, got %s", next)
+	}
+}
+
+func TestCycleNextAfterSkippedLongBreak(t *testing.T) {
+	cycle := Cycle{Pomodoros: 5, LastSessionType: SessionTypeWork}
+	if next := cycle.Next(4); next != SessionTypeLongBreak {
+		t.Errorf("expected long break once the interval is exceeded, got %s", next)
+	}
+}
+
+func TestTimerCompletionAdvancesCycle(t *testing.T) {
+	timer := NewTimer()
+	if err := timer.Start(10 * time.Millisecond); err != nil {
+		t.Fatalf("failed to start timer: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	if status := timer.GetStatus(); status != StatusCompleted {
+		t.Fatalf("expected completed status, got %v", status)
+	}
+
+	cycle := timer.GetCycle()
+	if cycle.Pomodoros != 1 || cycle.LastSessionType != SessionTypeWork {
+		t.Errorf("expected one completed pomodoro, got %+v", cycle)
+	}
+}
+
+func TestTimerStoppedSessionDoesNotAdvanceCycle(t *testing.T) {
+	timer := NewTimer()
+	if err := timer.Start(time.Minute); err != nil {
+		t.Fatalf("failed to start timer: %v", err)
+	}
+	if err := timer.Stop(); err != nil {
+		t.Fatalf("failed to stop timer: %v", err)
+	}
+
+	if cycle := timer.GetCycle(); cycle.Pomodoros != 0 {
+		t.Errorf("expected stopped session not to count, got %+v", cycle)
+	}
+}
+
+func TestTimerAutoStartsBreak(t *testing.T) {
+	timer := NewTimer()
+	cycleConfig := DefaultCycleConfig()
+	cycleConfig.BreakDuration = time.Minute
+	cycleConfig.AutoStartBreaks = true
+	timer.SetCycleConfig(cycleConfig)
+
+	if err := timer.Start(10 * time.Millisecond); err != nil {
+		t.Fatalf("failed to start timer: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	snapshot := timer.Snapshot()
+	if snapshot.Status != StatusRunning || snapshot.SessionType != SessionTypeBreak {
+		t.Fatalf("expected break to auto-start, got %s %s", snapshot.Status, snapshot.SessionType)
+	}
+	if snapshot.Duration != time.Minute {
+		t.Errorf("expected configured break duration, got %v", snapshot.Duration)
+	}
+	if snapshot.NextSessionType != SessionTypeWork {
+		t.Errorf("expected work to follow the break, got %s", snapshot.NextSessionType)
+	}
+}
+
+func TestTimerStartNext(t *testing.T) {
+	timer := NewTimer()
+	cycleConfig := DefaultCycleConfig()
+	cycleConfig.LongBreakInterval = 1
+	timer.SetCycleConfig(cycleConfig)
+	timer.cycle = Cycle{Pomodoros: 1, LastSessionType: SessionTypeWork}
+
+	if err := timer.StartNext(Annotation{}, false); err != nil {
+		t.Fatalf("failed to start next session: %v", err)
+	}
+	if timer.GetSessionType() != SessionTypeLongBreak {
+		t.Errorf("expected long break, got %s", timer.GetSessionType())
+	}
+	if timer.GetDuration() != cycleConfig.LongBreakDuration {
+		t.Errorf("expected long break duration, got %v", timer.GetDuration())
+	}
+}
+
+func TestTimerSkipRecordsSessionAndStartsNext(t *testing.T) {
+	timer := NewTimer()
+	timer.historyManager = &HistoryManager{historyFile: filepath.Join(t.TempDir(), "history.jsonl")}
+	cycleConfig := DefaultCycleConfig()
+	cycleConfig.BreakDuration = time.Minute
+	timer.SetCycleConfig(cycleConfig)
+
+	if err := timer.Start(time.Hour); err != nil {
+		t.Fatalf("failed to start timer: %v", err)
+	}
+	if err := timer.Skip(); err != nil {
+		t.Fatalf("failed to skip session: %v", err)
+	}
+
+	snapshot := timer.Snapshot()
+	if snapshot.Status != StatusRunning || snapshot.SessionType != SessionTypeBreak {
+		t.Fatalf("expected break to start, got %s %s", snapshot.Status, snapshot.SessionType)
+	}
+	if snapshot.Duration != time.Minute {
+		t.Errorf("expected configured break duration, got %v", snapshot.Duration)
+	}
+	if cycle := timer.GetCycle(); cycle.Pomodoros != 1 {
+		t.Errorf("expected skipped session to count as a cycle step, got %+v", cycle)
+	}
+
+	last, err := timer.historyManager.GetLastSession()
+	if err != nil {
+		t.Fatalf("failed to read history: %v", err)
+	}
+	if last.Type != SessionTypeWork || last.Completed || !last.Skipped {
+		t.Errorf("expected skipped, not completed work session, got %+v", last)
+	}
+}
+
+func TestTimerCancelDiscardsSession(t *testing.T) {
+	timer := NewTimer()
+	timer.historyManager = &HistoryManager{historyFile: filepath.Join(t.TempDir(), "history.jsonl")}
+
+	if err := timer.Start(time.Hour); err != nil {
+		t.Fatalf("failed to start timer: %v", err)
+	}
+	if err := timer.Pause(); err != nil {
+		t.Fatalf("failed to pause timer: %v", err)
+	}
+	if err := timer.Cancel(); err != nil {
+		t.Fatalf("failed to cancel timer: %v", err)
+	}
+
+	if status := timer.GetStatus(); status != StatusIdle {
+		t.Errorf("expected idle status, got %v", status)
+	}
+	if cycle := timer.GetCycle(); cycle.Pomodoros != 0 {
+		t.Errorf("expected cancelled session not to count, got %+v", cycle)
+	}
+	sessions, err := timer.historyManager.GetRecentSessions(10)
+	if err != nil {
+		t.Fatalf("failed to read history: %v", err)
+	}
+	if len(sessions) != 0 {
+		t.Errorf("expected no history entries, got %d", len(sessions))
+	}
+
+	if err := timer.Cancel(); err == nil {
+		t.Error("expected error cancelling an idle timer")
+	}
+}
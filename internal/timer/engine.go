@@ -22,6 +22,8 @@ const (
 	StatusRunning   TimerStatus = "running"
 	StatusPaused    TimerStatus = "paused"
 	StatusCompleted TimerStatus = "completed"
+	// StatusInterrupted is a session whose owning process died before it ended
+	StatusInterrupted TimerStatus = "interrupted"
 )
 
 // SessionType represents the type of timer session.
@@ -0,0 +1,64 @@
+package timer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rsmacapinlac/pomodux/internal/logger"
+	"github.com/rsmacapinlac/pomodux/internal/plugin"
+)
+
+// Extend adds time to the planned duration of the current session, or takes
+// it away when by is negative. A session cannot be shortened to end before
+// the time already elapsed.
+func (t *Timer) Extend(by time.Duration) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.checkCompletionLocked()
+	if t.status != StatusRunning && t.status != StatusPaused {
+		return fmt.Errorf("timer not running")
+	}
+	if t.stopwatchLocked() {
+		return fmt.Errorf("stopwatch sessions have no planned duration to extend")
+	}
+	if t.planReached {
+		return fmt.Errorf("session is already in overtime")
+	}
+	if by == 0 {
+		return fmt.Errorf("extension must not be zero")
+	}
+	if remaining := t.duration - t.elapsedLocked(); -by >= remaining {
+		return fmt.Errorf("cannot shorten the session by %v, only %v remains", -by, remaining.Round(time.Second))
+	}
+
+	t.duration += by
+
+	// Save state
+	if t.stateManager != nil {
+		if err := t.stateManager.SaveState(t); err != nil {
+			logger.Warn("Failed to save timer state", map[string]interface{}{"error": err.Error()})
+		}
+	}
+
+	// Emit timer extended event for plugins
+	if t.pluginManager != nil {
+		t.pluginManager.EmitEvent(plugin.Event{
+			Type:      plugin.EventTimerExtended,
+			Timestamp: t.clock.Now(),
+			Data: map[string]interface{}{
+				"session_type": string(t.sessionType),
+				"timer_name":   t.name,
+				"task":         t.annotation.Task,
+				"tags":         t.annotation.Tags,
+				"by":           int(by.Seconds()),
+				"duration":     int(t.duration.Seconds()),
+				"start_time":   t.startTime.Unix(),
+				"elapsed":      int(t.elapsedLocked().Seconds()),
+			},
+		})
+	}
+
+	logger.Info("Timer extended", map[string]interface{}{"session_type": t.sessionType, "by": by, "duration": t.duration})
+
+	return nil
+}
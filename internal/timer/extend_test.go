@@ -0,0 +1,59 @@
+package timer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rsmacapinlac/pomodux/internal/plugin"
+)
+
+func TestTimerExtend(t *testing.T) {
+	timer, clock := newTestTimer(t)
+
+	if err := timer.Start(25 * time.Minute); err != nil {
+		t.Fatalf("failed to start timer: %v", err)
+	}
+	clock.Advance(24 * time.Minute)
+	if err := timer.Extend(5 * time.Minute); err != nil {
+		t.Fatalf("failed to extend timer: %v", err)
+	}
+	clock.Advance(2 * time.Minute)
+
+	if status := timer.GetStatus(); status != StatusRunning {
+		t.Errorf("expected the extended session to still be running, got %s", status)
+	}
+	if remaining := timer.Snapshot().Remaining; remaining != 4*time.Minute {
+		t.Errorf("expected 4m remaining, got %v", remaining)
+	}
+	if err := timer.Extend(0); err == nil {
+		t.Errorf("expected a zero extension to be rejected")
+	}
+}
+
+func TestTimerShorten(t *testing.T) {
+	pm := plugin.NewPluginManager(t.TempDir())
+	events := captureEvents(t, pm, map[string][]string{"timer_extended": {"by", "duration"}})
+	timer, clock := newTestTimer(t)
+	timer.SetPluginManager(pm)
+
+	if err := timer.Start(25 * time.Minute); err != nil {
+		t.Fatalf("failed to start timer: %v", err)
+	}
+	clock.Advance(10 * time.Minute)
+	if err := timer.Extend(-5 * time.Minute); err != nil {
+		t.Fatalf("failed to shorten timer: %v", err)
+	}
+	if remaining := timer.Snapshot().Remaining; remaining != 10*time.Minute {
+		t.Errorf("expected 10m remaining, got %v", remaining)
+	}
+	if state, err := timer.stateManager.LoadState(); err != nil || state.Duration != 20*time.Minute {
+		t.Errorf("expected the shortened duration to be saved, got %+v (%v)", state, err)
+	}
+	if err := timer.Extend(-10 * time.Minute); err == nil {
+		t.Errorf("expected shortening past the elapsed time to be rejected")
+	}
+
+	if events := events(); events != "timer_extended -300 1200" {
+		t.Errorf("expected one event shortening the session by 5m, got %q", events)
+	}
+}
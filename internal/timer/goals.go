@@ -0,0 +1,205 @@
+package timer
+
+import (
+	"time"
+
+	"github.com/rsmacapinlac/pomodux/internal/logger"
+	"github.com/rsmacapinlac/pomodux/internal/plugin"
+)
+
+// GoalPeriod is the stretch of time a goal covers.
+type GoalPeriod string
+
+const (
+	GoalDaily  GoalPeriod = "daily"
+	GoalWeekly GoalPeriod = "weekly"
+)
+
+// GoalMetric is what a goal counts.
+type GoalMetric string
+
+const (
+	// GoalPomodoros counts completed work sessions
+	GoalPomodoros GoalMetric = "pomodoros"
+	// GoalFocus adds up the time actually spent in work sessions
+	GoalFocus GoalMetric = "focus"
+)
+
+// Goals are the targets to reach each day and week. A zero target is not set.
+type Goals struct {
+	DailyPomodoros  int
+	DailyFocus      time.Duration
+	WeeklyPomodoros int
+	WeeklyFocus     time.Duration
+	// Days are the days daily goals apply on; every day when empty
+	Days []time.Weekday
+}
+
+// Active reports whether any goal is set.
+func (g Goals) Active() bool {
+	return g.DailyPomodoros > 0 || g.DailyFocus > 0 || g.WeeklyPomodoros > 0 || g.WeeklyFocus > 0
+}
+
+// appliesOn reports whether daily goals apply on day.
+func (g Goals) appliesOn(day time.Weekday) bool {
+	if len(g.Days) == 0 {
+		return true
+	}
+	for _, d := range g.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// GoalProgress is how far one goal is towards its target.
+type GoalProgress struct {
+	Period GoalPeriod
+	Metric GoalMetric
+	// Start is when the goal's current day or week began
+	Start time.Time
+	// Target and Done are numbers of pomodoros for pomodoro goals
+	Target int
+	Done   int
+	// TargetFocus and Focus are used instead for focus goals
+	TargetFocus time.Duration
+	Focus       time.Duration
+}
+
+// Name identifies the goal, such as "daily_pomodoros".
+func (p GoalProgress) Name() string {
+	return string(p.Period) + "_" + string(p.Metric)
+}
+
+// Fraction returns how much of the target is done, from 0 up; it passes 1
+// once the goal is exceeded.
+func (p GoalProgress) Fraction() float64 {
+	if p.Metric == GoalFocus {
+		return float64(p.Focus) / float64(p.TargetFocus)
+	}
+	return float64(p.Done) / float64(p.Target)
+}
+
+// Reached reports whether the target has been met.
+func (p GoalProgress) Reached() bool {
+	return p.Fraction() >= 1
+}
+
+// GoalsProgress measures progress towards each goal that is set, over the
+// day and the week (starting on Monday) containing now. Only sessions on the
+// default timer that count as work, given the user-defined types, count.
+// Daily goals are left out on days they do not apply on.
+func GoalsProgress(goals Goals, types SessionTypes, sessions []SessionRecord, now time.Time) []GoalProgress {
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	weekStart := dayStart.AddDate(0, 0, -((int(now.Weekday()) + 6) % 7))
+
+	var progress []GoalProgress
+	if goals.appliesOn(now.Weekday()) {
+		if goals.DailyPomodoros > 0 {
+			progress = append(progress, GoalProgress{Period: GoalDaily, Metric: GoalPomodoros, Start: dayStart, Target: goals.DailyPomodoros})
+		}
+		if goals.DailyFocus > 0 {
+			progress = append(progress, GoalProgress{Period: GoalDaily, Metric: GoalFocus, Start: dayStart, TargetFocus: goals.DailyFocus})
+		}
+	}
+	if goals.WeeklyPomodoros > 0 {
+		progress = append(progress, GoalProgress{Period: GoalWeekly, Metric: GoalPomodoros, Start: weekStart, Target: goals.WeeklyPomodoros})
+	}
+	if goals.WeeklyFocus > 0 {
+		progress = append(progress, GoalProgress{Period: GoalWeekly, Metric: GoalFocus, Start: weekStart, TargetFocus: goals.WeeklyFocus})
+	}
+
+	for _, session := range sessions {
+		if !types.CountsAsWork(session.Type) || session.TimerName != "" || session.StartTime.After(now) {
+			continue
+		}
+		for i := range progress {
+			if session.StartTime.Before(progress[i].Start) {
+				continue
+			}
+			if session.Completed && types.IsPomodoro(session) {
+				progress[i].Done++
+			}
+			progress[i].Focus += session.ActiveDuration()
+		}
+	}
+	return progress
+}
+
+// SetGoals sets the goals the timer tells plugins about reaching.
+func (t *Timer) SetGoals(goals Goals) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.goals = goals
+}
+
+// addSessionLocked records session in history and tells plugins about any
+// goal it reaches and any change to the streak. The caller must hold t.mu.
+func (t *Timer) addSessionLocked(session SessionRecord) {
+	var before []SessionRecord
+	notify := (t.goals.Active() || t.streaks.Enabled()) && t.pluginManager != nil
+	if notify {
+		var err error
+		if before, err = t.historyManager.GetAllSessions(); err != nil {
+			logger.Warn("Failed to load session history for goals and streaks", map[string]interface{}{"error": err.Error()})
+			notify = false
+		}
+	}
+
+	// History recording shouldn't prevent the timer from changing state
+	if err := t.historyManager.AddSession(session); err != nil {
+		logger.Warn("Failed to add session to history", map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	if !notify {
+		return
+	}
+
+	// Recording a session again replaces it
+	after := []SessionRecord{session}
+	for _, s := range before {
+		if session.ID == "" || s.ID != session.ID {
+			after = append(after, s)
+		}
+	}
+	if t.goals.Active() {
+		t.emitGoalsReachedLocked(before, after)
+	}
+	if t.streaks.Enabled() {
+		t.emitStreakEventsLocked(before, after)
+	}
+}
+
+// emitGoalsReachedLocked emits a goal reached event for every goal that was
+// not reached in the history before, but is in the history after. The
+// caller must hold t.mu.
+func (t *Timer) emitGoalsReachedLocked(before, after []SessionRecord) {
+	now := t.clock.Now()
+	was := GoalsProgress(t.goals, t.sessionTypes, before, now)
+	is := GoalsProgress(t.goals, t.sessionTypes, after, now)
+	for i, goal := range is {
+		if !goal.Reached() || was[i].Reached() {
+			continue
+		}
+
+		data := map[string]interface{}{
+			"goal":   goal.Name(),
+			"period": string(goal.Period),
+			"metric": string(goal.Metric),
+			"target": goal.Target,
+			"done":   goal.Done,
+		}
+		if goal.Metric == GoalFocus {
+			data["target"] = int(goal.TargetFocus.Seconds())
+			data["done"] = int(goal.Focus.Seconds())
+		}
+		t.pluginManager.EmitEvent(plugin.Event{
+			Type:      plugin.EventGoalReached,
+			Timestamp: now,
+			Data:      data,
+		})
+		logger.Info("Goal reached", map[string]interface{}{"goal": goal.Name()})
+	}
+}
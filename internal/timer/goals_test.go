@@ -0,0 +1,79 @@
+package timer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rsmacapinlac/pomodux/internal/plugin"
+)
+
+func TestGoalsProgress(t *testing.T) {
+	// Wednesday; the week started on Monday the 6th
+	now := time.Date(2025, 1, 8, 15, 0, 0, 0, time.UTC)
+	work := func(start time.Time, active time.Duration, completed bool) SessionRecord {
+		return SessionRecord{Type: SessionTypeWork, Duration: 25 * time.Minute, StartTime: start, EndTime: start.Add(active), Completed: completed}
+	}
+	sessions := []SessionRecord{
+		work(now.Add(-2*time.Hour), 25*time.Minute, true),
+		work(now.Add(-time.Hour), 10*time.Minute, false),
+		work(now.AddDate(0, 0, -2), 25*time.Minute, true),
+		work(now.AddDate(0, 0, -3), 25*time.Minute, true), // last week
+		{Type: SessionTypeBreak, StartTime: now.Add(-30 * time.Minute), EndTime: now.Add(-25 * time.Minute), Completed: true},
+		{Type: SessionTypeWork, TimerName: "deploy", StartTime: now.Add(-20 * time.Minute), EndTime: now.Add(-10 * time.Minute), Completed: true},
+	}
+	goals := Goals{DailyPomodoros: 2, DailyFocus: time.Hour, WeeklyPomodoros: 2, WeeklyFocus: 20 * time.Hour}
+
+	progress := GoalsProgress(goals, nil, sessions, now)
+	if len(progress) != 4 {
+		t.Fatalf("expected 4 goals, got %d", len(progress))
+	}
+
+	daily := progress[0]
+	if daily.Name() != "daily_pomodoros" || daily.Done != 1 || daily.Reached() {
+		t.Errorf("expected 1 of 2 daily pomodoros, got %+v", daily)
+	}
+	if focus := progress[1]; focus.Focus != 35*time.Minute {
+		t.Errorf("expected 35m focused today, got %v", focus.Focus)
+	}
+	weekly := progress[2]
+	if weekly.Name() != "weekly_pomodoros" || weekly.Done != 2 || !weekly.Reached() {
+		t.Errorf("expected weekly pomodoros to be reached, got %+v", weekly)
+	}
+	if !weekly.Start.Equal(time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the week to start on Monday, got %v", weekly.Start)
+	}
+
+	// Daily goals do not apply on days left out
+	goals.Days = []time.Weekday{time.Monday, time.Tuesday}
+	if progress := GoalsProgress(goals, nil, sessions, now); len(progress) != 2 || progress[0].Period != GoalWeekly {
+		t.Errorf("expected only weekly goals on Wednesday, got %+v", progress)
+	}
+
+	// Time tracked with the stopwatch is focus, but not a pomodoro
+	goals.Days = nil
+	tracked := SessionRecord{Type: SessionTypeWork, StartTime: now.Add(-5 * time.Minute), EndTime: now.Add(-3 * time.Minute), Completed: true}
+	progress = GoalsProgress(goals, nil, append(sessions, tracked), now)
+	if progress[0].Done != 1 || progress[1].Focus != 37*time.Minute {
+		t.Errorf("expected the tracked session to add focus only, got %+v", progress[:2])
+	}
+}
+
+func TestTimerEmitsGoalReached(t *testing.T) {
+	pm := plugin.NewPluginManager(t.TempDir())
+	events := captureEvents(t, pm, map[string][]string{"goal_reached": {"goal", "done"}})
+	timer, clock := newTestTimer(t)
+	timer.SetPluginManager(pm)
+	timer.SetGoals(Goals{DailyPomodoros: 2})
+
+	for i := 0; i < 3; i++ {
+		if err := timer.Start(25 * time.Minute); err != nil {
+			t.Fatalf("failed to start session: %v", err)
+		}
+		clock.Advance(25 * time.Minute)
+		timer.GetStatus()
+	}
+
+	if events := events(); events != "goal_reached daily_pomodoros 2" {
+		t.Errorf("expected the goal to be reached once, on the second pomodoro, got %q", events)
+	}
+}
@@ -0,0 +1,84 @@
+package timer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rsmacapinlac/pomodux/internal/plugin"
+)
+
+// testStart is when test sessions start: 9am on Monday 6 January 2025.
+var testStart = time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+
+// newTestManagers returns state and history managers for files in a
+// temporary directory.
+func newTestManagers(t *testing.T) (*StateManager, *HistoryManager) {
+	t.Helper()
+	dir := t.TempDir()
+	sm := &StateManager{stateFile: filepath.Join(dir, "timer_state.json")}
+	hm := &HistoryManager{historyFile: filepath.Join(dir, "session_history.jsonl")}
+	return sm, hm
+}
+
+// newTestTimer returns a timer that keeps its state and history in a
+// temporary directory, on a fake clock set to testStart.
+func newTestTimer(t *testing.T) (*Timer, *FakeClock) {
+	t.Helper()
+	sm, hm := newTestManagers(t)
+	clock := NewFakeClock(testStart)
+	timer := NewTimerWithManagers(sm, hm, clock)
+	t.Cleanup(timer.Close)
+	return timer, clock
+}
+
+// captureEvents loads a plugin into pm that records events of the types in
+// fields, each as a line with its type and the named fields of its data. The
+// returned function shuts pm down, so every event has been handled, and
+// returns the lines.
+func captureEvents(t *testing.T, pm *plugin.PluginManager, fields map[string][]string) func() string {
+	t.Helper()
+	eventsFile := filepath.Join(t.TempDir(), "events.txt")
+
+	types := make([]string, 0, len(fields))
+	for eventType := range fields {
+		types = append(types, eventType)
+	}
+	sort.Strings(types)
+
+	var hooks strings.Builder
+	for _, eventType := range types {
+		var values []string
+		for _, field := range fields[eventType] {
+			values = append(values, fmt.Sprintf("tostring(event.data.%s)", field))
+		}
+		fmt.Fprintf(&hooks, "pomodux.register_hook(%q, function(event) record(%q, {%s}) end)\n",
+			eventType, eventType, strings.Join(values, ", "))
+	}
+
+	err := pm.LoadPlugin("capture", fmt.Sprintf(`
+pomodux.register_plugin({name = "capture", version = "1.0.0", description = "Capture", author = "Test"})
+local function record(eventType, values)
+    local f = io.open(%q, "a")
+    f:write(eventType .. " " .. table.concat(values, " ") .. "\n")
+    f:close()
+end
+%s`, eventsFile, hooks.String()))
+	if err != nil {
+		t.Fatalf("failed to load plugin: %v", err)
+	}
+
+	return func() string {
+		t.Helper()
+		pm.Shutdown()
+		data, err := os.ReadFile(eventsFile)
+		if err != nil && !os.IsNotExist(err) {
+			t.Fatalf("failed to read captured events: %v", err)
+		}
+		return strings.TrimSpace(string(data))
+	}
+}
@@ -1,26 +1,73 @@
 package timer
 
 import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"sync"
 	"time"
+
+	"github.com/rsmacapinlac/pomodux/internal/logger"
 )
 
 // SessionRecord represents a completed timer session
 type SessionRecord struct {
-	Type      SessionType   `json:"type"`
+	// ID identifies the session; recording a session with a known ID updates its entry
+	ID string `json:"id,omitempty"`
+	// TimerName is the named timer the session ran on; empty for the default timer
+	TimerName string      `json:"timer_name,omitempty"`
+	Type      SessionType `json:"type"`
+	Annotation
 	Duration  time.Duration `json:"duration"`
 	StartTime time.Time     `json:"start_time"`
 	EndTime   time.Time     `json:"end_time"`
 	Completed bool          `json:"completed"`
+	// Skipped is set when the session was ended early to move on in the cycle
+	Skipped bool `json:"skipped,omitempty"`
+	// Interrupted is set when the session was cut short by a crash or reboot
+	Interrupted bool `json:"interrupted,omitempty"`
+	// Pauses lists every time the session was paused
+	Pauses []PauseInterval `json:"pauses,omitempty"`
+	// Notes were added while the session was running
+	Notes []Note `json:"notes,omitempty"`
+	// Overtime is how long the session ran past its planned Duration
+	Overtime time.Duration `json:"overtime,omitempty"`
+}
+
+// HistoryStore persists the record of every timer session.
+type HistoryStore interface {
+	// AddSession adds a session, replacing any existing record with the same ID
+	AddSession(session SessionRecord) error
+	// GetLastSession returns the most recent session
+	GetLastSession() (*SessionRecord, error)
+	// GetRecentSessions returns up to count sessions, most recent first
+	GetRecentSessions(count int) ([]SessionRecord, error)
+	// GetAllSessions returns every recorded session, most recent first
+	GetAllSessions() ([]SessionRecord, error)
 }
 
-// HistoryManager handles session history persistence
+// compactMinSuperseded is the number of superseded lines the history file
+// may hold before it is compacted, as long as they are also at least half of it.
+const compactMinSuperseded = 100
+
+// HistoryManager is the file-backed HistoryStore. History is kept as JSON
+// Lines: every recorded session is appended as one line, and a later line
+// with the same ID supersedes an earlier one. The file is compacted once
+// superseded lines make up much of it. Nothing is ever dropped.
 type HistoryManager struct {
 	historyFile string
+	// legacyFile is the JSON array file used by earlier versions; it is migrated on first use
+	legacyFile string
+	// checkedSize is the size of the history file when it was last written
+	// whole or checked for compaction; zero until then
+	checkedSize int64
 	mu          sync.Mutex
 }
 
@@ -31,107 +78,282 @@ func NewHistoryManager() (*HistoryManager, error) {
 		return nil, fmt.Errorf("failed to get state directory: %w", err)
 	}
 
-	historyFile := filepath.Join(stateDir, "session_history.json")
-	return &HistoryManager{historyFile: historyFile}, nil
+	return &HistoryManager{
+		historyFile: filepath.Join(stateDir, "session_history.jsonl"),
+		legacyFile:  filepath.Join(stateDir, "session_history.json"),
+	}, nil
 }
 
-// AddSession adds a completed session to history
+// AddSession adds a session to history, replacing any existing record with the same ID
 func (hm *HistoryManager) AddSession(session SessionRecord) error {
 	hm.mu.Lock()
 	defer hm.mu.Unlock()
 
-	// Load existing history
-	history, err := hm.loadHistory()
+	// Hold the file lock across the whole read-modify-write so that sessions
+	// recorded by another process at the same time are not lost
+	return withFileLock(hm.historyFile, func() error {
+		if err := hm.migrateLegacy(); err != nil {
+			return err
+		}
+
+		if err := hm.appendRecord(session); err != nil {
+			return err
+		}
+
+		return hm.compactIfNeeded()
+	})
+}
+
+// GetLastSession returns the most recent session
+func (hm *HistoryManager) GetLastSession() (*SessionRecord, error) {
+	history, err := hm.GetAllSessions()
 	if err != nil {
-		return fmt.Errorf("failed to load history: %w", err)
+		return nil, err
 	}
 
-	// Add new session to the beginning (most recent first)
-	history = append([]SessionRecord{session}, history...)
+	if len(history) == 0 {
+		return nil, fmt.Errorf("no session history found")
+	}
+
+	return &history[0], nil
+}
 
-	// Keep only last 100 sessions to prevent file from growing too large
-	if len(history) > 100 {
-		history = history[:100]
+// GetRecentSessions returns the most recent N sessions
+func (hm *HistoryManager) GetRecentSessions(count int) ([]SessionRecord, error) {
+	history, err := hm.GetAllSessions()
+	if err != nil {
+		return nil, err
 	}
 
-	// Save updated history
-	return hm.saveHistory(history)
+	// Return up to 'count' sessions, or all if less than count
+	if count < len(history) {
+		history = history[:count]
+	}
+
+	return history, nil
 }
 
-// GetLastSession returns the most recent completed session
-func (hm *HistoryManager) GetLastSession() (*SessionRecord, error) {
+// GetAllSessions returns every recorded session, most recent first
+func (hm *HistoryManager) GetAllSessions() ([]SessionRecord, error) {
 	hm.mu.Lock()
 	defer hm.mu.Unlock()
 
-	history, err := hm.loadHistory()
+	var history []SessionRecord
+	err := withFileLock(hm.historyFile, func() error {
+		if err := hm.migrateLegacy(); err != nil {
+			return err
+		}
+
+		records, _, err := hm.loadHistory()
+		if err != nil {
+			return fmt.Errorf("failed to load history: %w", err)
+		}
+		history = records
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to load history: %w", err)
+		return nil, err
 	}
 
-	if len(history) == 0 {
-		return nil, fmt.Errorf("no session history found")
+	// The file is in recording order; callers want the most recent first
+	for i, j := 0, len(history)-1; i < j; i, j = i+1, j-1 {
+		history[i], history[j] = history[j], history[i]
 	}
-
-	return &history[0], nil
+	return history, nil
 }
 
-// GetRecentSessions returns the most recent N completed sessions
-func (hm *HistoryManager) GetRecentSessions(count int) ([]SessionRecord, error) {
+// Compact rewrites the history file with one line per session, dropping
+// superseded lines.
+func (hm *HistoryManager) Compact() error {
 	hm.mu.Lock()
 	defer hm.mu.Unlock()
 
-	history, err := hm.loadHistory()
+	return withFileLock(hm.historyFile, func() error {
+		history, _, err := hm.loadHistory()
+		if err != nil {
+			return fmt.Errorf("failed to load history: %w", err)
+		}
+		return hm.saveHistory(history)
+	})
+}
+
+// loadHistory reads the history file in recording order, applying later
+// lines over earlier lines with the same ID. It also returns the number of
+// lines read. The caller must hold the file lock.
+func (hm *HistoryManager) loadHistory() ([]SessionRecord, int, error) {
+	f, err := os.Open(hm.historyFile)
+	if os.IsNotExist(err) {
+		return []SessionRecord{}, 0, nil
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to load history: %w", err)
+		return nil, 0, fmt.Errorf("failed to read history file: %w", err)
 	}
+	defer f.Close()
 
-	if len(history) == 0 {
-		return []SessionRecord{}, nil
+	history := []SessionRecord{}
+	index := make(map[string]int)
+	lines := 0
+
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			lines++
+			var session SessionRecord
+			if jsonErr := json.Unmarshal(line, &session); jsonErr != nil {
+				// A line cut short by a crash mid-write; the rest of the file is still good
+				logger.Warn("Skipping unreadable history line", map[string]interface{}{"line": lines, "error": jsonErr.Error()})
+			} else if i, ok := index[session.ID]; ok && session.ID != "" {
+				history[i] = session
+			} else {
+				if session.ID != "" {
+					index[session.ID] = len(history)
+				}
+				history = append(history, session)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to read history file: %w", err)
+		}
 	}
 
-	// Return up to 'count' sessions, or all if less than count
-	if count > len(history) {
-		count = len(history)
+	return history, lines, nil
+}
+
+// appendRecord appends one session to the history file. The caller must hold the file lock.
+func (hm *HistoryManager) appendRecord(session SessionRecord) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	f, err := os.OpenFile(hm.historyFile, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open history file: %w", err)
 	}
+	defer f.Close()
 
-	return history[:count], nil
+	// Start on a fresh line if a previous write was cut short
+	if info, err := f.Stat(); err == nil && info.Size() > 0 {
+		last := make([]byte, 1)
+		if _, err := f.ReadAt(last, info.Size()-1); err == nil && last[0] != '\n' {
+			data = append([]byte{'\n'}, data...)
+		}
+	}
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		return fmt.Errorf("failed to sync history file: %w", err)
+	}
+	return nil
 }
 
-// loadHistory loads session history from file
-func (hm *HistoryManager) loadHistory() ([]SessionRecord, error) {
-	if _, err := os.Stat(hm.historyFile); os.IsNotExist(err) {
-		return []SessionRecord{}, nil
+// compactIfNeeded compacts the history file once superseded lines make up
+// a large part of it. Superseded lines can only be half of the file once it
+// has doubled in size since it was last checked, so it is only read then.
+// The caller must hold the file lock.
+func (hm *HistoryManager) compactIfNeeded() error {
+	info, err := os.Stat(hm.historyFile)
+	if err != nil {
+		return fmt.Errorf("failed to stat history file: %w", err)
+	}
+	if hm.checkedSize > 0 && info.Size() < 2*hm.checkedSize {
+		return nil
 	}
 
-	data, err := os.ReadFile(hm.historyFile)
+	history, lines, err := hm.loadHistory()
 	if err != nil {
-		return nil, fmt.Errorf("failed to read history file: %w", err)
+		return fmt.Errorf("failed to load history: %w", err)
 	}
 
-	var history []SessionRecord
-	if err := json.Unmarshal(data, &history); err != nil {
-		return nil, fmt.Errorf("failed to parse history file: %w", err)
+	superseded := lines - len(history)
+	if superseded < compactMinSuperseded || superseded < len(history) {
+		hm.checkedSize = info.Size()
+		return nil
 	}
 
-	return history, nil
+	logger.Debug("Compacting session history", map[string]interface{}{"sessions": len(history), "lines": lines})
+	return hm.saveHistory(history)
 }
 
-// saveHistory saves session history to file
+// saveHistory replaces the history file with one line per session, in
+// recording order. The caller must hold the file lock.
 func (hm *HistoryManager) saveHistory(history []SessionRecord) error {
-	// Ensure state directory exists
-	stateDir := filepath.Dir(hm.historyFile)
-	if err := os.MkdirAll(stateDir, 0750); err != nil {
-		return fmt.Errorf("failed to create state directory: %w", err)
+	var buf bytes.Buffer
+	for _, session := range history {
+		data, err := json.Marshal(session)
+		if err != nil {
+			return fmt.Errorf("failed to marshal session: %w", err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+
+	if err := writeFileAtomic(hm.historyFile, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write history file: %w", err)
 	}
+	hm.checkedSize = int64(buf.Len())
 
-	data, err := json.Marshal(history)
+	return nil
+}
+
+// migrateLegacy moves sessions from the JSON array file used by earlier
+// versions into the history file, then renames the old file out of the way
+// so that it is only migrated once. The caller must hold the file lock.
+func (hm *HistoryManager) migrateLegacy() error {
+	if hm.legacyFile == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(hm.legacyFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
 	if err != nil {
-		return fmt.Errorf("failed to marshal history: %w", err)
+		return fmt.Errorf("failed to read legacy history file: %w", err)
 	}
 
-	if err := os.WriteFile(hm.historyFile, data, 0600); err != nil {
-		return fmt.Errorf("failed to write history file: %w", err)
+	var legacy []SessionRecord
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return fmt.Errorf("failed to parse legacy history file: %w", err)
 	}
+	// Sessions recorded before sessions had IDs need one to be edited
+	assignMissingIDs(legacy)
 
+	existing, _, err := hm.loadHistory()
+	if err != nil {
+		return fmt.Errorf("failed to load history: %w", err)
+	}
+
+	// The legacy file is most recent first; the history file is in recording order
+	history := make([]SessionRecord, 0, len(legacy)+len(existing))
+	for i := len(legacy) - 1; i >= 0; i-- {
+		history = append(history, legacy[i])
+	}
+	history = append(history, existing...)
+
+	if err := hm.saveHistory(history); err != nil {
+		return err
+	}
+	if err := os.Rename(hm.legacyFile, hm.legacyFile+".migrated"); err != nil {
+		return fmt.Errorf("failed to retire legacy history file: %w", err)
+	}
+
+	logger.Info("Migrated session history", map[string]interface{}{"sessions": len(legacy), "history_file": hm.historyFile})
 	return nil
 }
+
+// newSessionID returns a random identifier for a new session.
+func newSessionID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand does not fail on supported platforms; fall back to the clock
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(b)
+}
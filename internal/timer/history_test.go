@@ -0,0 +1,208 @@
+package timer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHistoryManagerAddSessionUpsertsByID(t *testing.T) {
+	hm := &HistoryManager{historyFile: filepath.Join(t.TempDir(), "history.jsonl")}
+	start := testStart
+
+	sessions := []SessionRecord{
+		{ID: "a", Type: SessionTypeWork, StartTime: start, Completed: false},
+		{ID: "b", Type: SessionTypeBreak, StartTime: start.Add(time.Hour)},
+		{ID: "a", Type: SessionTypeWork, StartTime: start, Completed: true},
+		{Type: SessionTypeWork},
+		{Type: SessionTypeWork},
+	}
+	for _, session := range sessions {
+		if err := hm.AddSession(session); err != nil {
+			t.Fatalf("failed to add session: %v", err)
+		}
+	}
+
+	history, err := hm.GetRecentSessions(10)
+	if err != nil {
+		t.Fatalf("failed to read history: %v", err)
+	}
+	if len(history) != 4 {
+		t.Fatalf("expected 4 records, got %d", len(history))
+	}
+	if history[3].ID != "a" || !history[3].Completed {
+		t.Errorf("expected session a to be updated in place, got %+v", history[3])
+	}
+}
+
+func TestTimerRecordsEachSessionOnce(t *testing.T) {
+	timer, clock := newTestTimer(t)
+
+	if err := timer.Start(time.Minute); err != nil {
+		t.Fatalf("failed to start timer: %v", err)
+	}
+	id := timer.GetSessionID()
+	if id == "" {
+		t.Fatal("expected session to have an ID")
+	}
+
+	clock.Advance(time.Minute)
+	timer.GetStatus()
+	timer.GetProgress()
+	if err := timer.Reset(); err != nil {
+		t.Fatalf("failed to reset timer: %v", err)
+	}
+
+	if err := timer.Start(time.Minute); err != nil {
+		t.Fatalf("failed to start timer: %v", err)
+	}
+	if timer.GetSessionID() == id {
+		t.Error("expected a new session to get a new ID")
+	}
+	clock.Advance(time.Minute)
+	if err := timer.Stop(); err != nil {
+		t.Fatalf("failed to stop timer: %v", err)
+	}
+
+	history, err := timer.historyManager.GetRecentSessions(10)
+	if err != nil {
+		t.Fatalf("failed to read history: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected one record per session, got %d", len(history))
+	}
+	for _, session := range history {
+		if !session.Completed {
+			t.Errorf("expected completed session, got %+v", session)
+		}
+	}
+}
+
+func TestHistoryManagerKeepsEverySession(t *testing.T) {
+	hm := &HistoryManager{historyFile: filepath.Join(t.TempDir(), "history.jsonl")}
+
+	for i := 0; i < 250; i++ {
+		if err := hm.AddSession(SessionRecord{ID: fmt.Sprintf("s%d", i), Type: SessionTypeWork}); err != nil {
+			t.Fatalf("failed to add session: %v", err)
+		}
+	}
+
+	history, err := hm.GetAllSessions()
+	if err != nil {
+		t.Fatalf("failed to read history: %v", err)
+	}
+	if len(history) != 250 {
+		t.Fatalf("expected every session to be kept, got %d", len(history))
+	}
+	if history[0].ID != "s249" || history[249].ID != "s0" {
+		t.Errorf("expected most recent session first, got %s ... %s", history[0].ID, history[249].ID)
+	}
+}
+
+func TestHistoryManagerCompactsSupersededLines(t *testing.T) {
+	hm := &HistoryManager{historyFile: filepath.Join(t.TempDir(), "history.jsonl")}
+
+	// The file is checked each time it doubles in size, so it is compacted
+	// at the first check with enough superseded lines
+	count := 3 * compactMinSuperseded
+	for i := 0; i < count; i++ {
+		if err := hm.AddSession(SessionRecord{ID: "a", Type: SessionTypeWork, Duration: time.Duration(i)}); err != nil {
+			t.Fatalf("failed to add session: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(hm.historyFile)
+	if err != nil {
+		t.Fatalf("failed to read history file: %v", err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines > 2*compactMinSuperseded {
+		t.Errorf("expected superseded lines to be compacted away, got %d lines", lines)
+	}
+
+	last, err := hm.GetLastSession()
+	if err != nil {
+		t.Fatalf("failed to read history: %v", err)
+	}
+	if last.Duration != time.Duration(count-1) {
+		t.Errorf("expected the latest version of the session, got %+v", last)
+	}
+}
+
+func TestHistoryManagerSkipsTruncatedLine(t *testing.T) {
+	hm := &HistoryManager{historyFile: filepath.Join(t.TempDir(), "history.jsonl")}
+	if err := hm.AddSession(SessionRecord{ID: "a", Type: SessionTypeWork}); err != nil {
+		t.Fatalf("failed to add session: %v", err)
+	}
+
+	// Simulate a crash part way through appending a line
+	f, err := os.OpenFile(hm.historyFile, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatalf("failed to open history file: %v", err)
+	}
+	if _, err := f.WriteString(`{"id":"b","ty`); err != nil {
+		t.Fatalf("failed to write history file: %v", err)
+	}
+	f.Close()
+
+	if err := hm.AddSession(SessionRecord{ID: "c", Type: SessionTypeBreak}); err != nil {
+		t.Fatalf("failed to add session: %v", err)
+	}
+
+	history, err := hm.GetAllSessions()
+	if err != nil {
+		t.Fatalf("failed to read history: %v", err)
+	}
+	if len(history) != 2 || history[0].ID != "c" || history[1].ID != "a" {
+		t.Errorf("expected sessions c and a, got %+v", history)
+	}
+}
+
+func TestHistoryManagerMigratesLegacyFile(t *testing.T) {
+	dir := t.TempDir()
+	hm := &HistoryManager{
+		historyFile: filepath.Join(dir, "session_history.jsonl"),
+		legacyFile:  filepath.Join(dir, "session_history.json"),
+	}
+
+	// The legacy file is a JSON array, most recent first
+	legacy := []SessionRecord{
+		{Type: SessionTypeBreak, Completed: true},
+		{Type: SessionTypeWork, Completed: true},
+	}
+	data, err := json.Marshal(legacy)
+	if err != nil {
+		t.Fatalf("failed to marshal legacy history: %v", err)
+	}
+	if err := os.WriteFile(hm.legacyFile, data, 0600); err != nil {
+		t.Fatalf("failed to write legacy history: %v", err)
+	}
+
+	if err := hm.AddSession(SessionRecord{ID: "new", Type: SessionTypeWork}); err != nil {
+		t.Fatalf("failed to add session: %v", err)
+	}
+
+	history, err := hm.GetAllSessions()
+	if err != nil {
+		t.Fatalf("failed to read history: %v", err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("expected legacy sessions to be migrated, got %d", len(history))
+	}
+	if history[0].ID != "new" || history[1].Type != SessionTypeBreak || history[2].Type != SessionTypeWork {
+		t.Errorf("expected migrated sessions to keep their order, got %+v", history)
+	}
+	if history[1].ID == "" || history[2].ID == "" {
+		t.Errorf("expected migrated sessions to be given IDs, got %+v", history)
+	}
+
+	if _, err := os.Stat(hm.legacyFile); !os.IsNotExist(err) {
+		t.Error("expected legacy file to be retired after migration")
+	}
+	if _, err := os.Stat(hm.legacyFile + ".migrated"); err != nil {
+		t.Errorf("expected legacy file to be kept as a backup: %v", err)
+	}
+}
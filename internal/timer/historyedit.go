@@ -0,0 +1,312 @@
+package timer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// HistoryEditAction is the kind of change made to history by hand.
+type HistoryEditAction string
+
+const (
+	HistoryEditAdd    HistoryEditAction = "add"
+	HistoryEditUpdate HistoryEditAction = "edit"
+	HistoryEditRemove HistoryEditAction = "remove"
+)
+
+// HistoryEdit is a change made to history by hand. It is kept until the next
+// edit so that it can be undone.
+type HistoryEdit struct {
+	Action HistoryEditAction `json:"action"`
+	// Before is the session as it was; nil for an added session
+	Before *SessionRecord `json:"before,omitempty"`
+	// After is the session as it is now; nil for a removed session
+	After *SessionRecord `json:"after,omitempty"`
+}
+
+// OverlapError reports that a session would overlap another session on the
+// same timer.
+type OverlapError struct {
+	Session SessionRecord
+}
+
+func (e *OverlapError) Error() string {
+	return fmt.Sprintf("overlaps %s session %s from %s to %s", e.Session.Type, e.Session.ID,
+		e.Session.StartTime.Format("2006-01-02 15:04"), e.Session.EndTime.Format("15:04"))
+}
+
+// Retime moves the session to run from start to end. Pauses outside the new
+// times are dropped or cut short, and overtime is worked out again.
+func (s *SessionRecord) Retime(start, end time.Time) {
+	var pauses []PauseInterval
+	for _, pause := range s.Pauses {
+		if !pause.End.After(start) || !pause.Start.Before(end) {
+			continue
+		}
+		if pause.Start.Before(start) {
+			pause.Start = start
+		}
+		if pause.End.After(end) {
+			pause.End = end
+		}
+		pauses = append(pauses, pause)
+	}
+	s.StartTime = start
+	s.EndTime = end
+	s.Pauses = pauses
+
+	if s.Overtime > 0 {
+		s.Overtime = 0
+		if over := s.ActiveDuration() - s.Duration; over > 0 {
+			s.Overtime = over
+		}
+	}
+}
+
+// FindSession returns the session whose ID is id, or starts with it.
+func (hm *HistoryManager) FindSession(id string) (SessionRecord, error) {
+	history, err := hm.GetAllSessions()
+	if err != nil {
+		return SessionRecord{}, err
+	}
+	i, err := findSession(history, id)
+	if err != nil {
+		return SessionRecord{}, err
+	}
+	return history[i], nil
+}
+
+// InsertSession adds a session entered by hand, giving it an ID. It is kept
+// in order of start time, and must not overlap another session on the same
+// timer.
+func (hm *HistoryManager) InsertSession(session SessionRecord) (SessionRecord, error) {
+	session.ID = newSessionID()
+	err := hm.edit(func(history []SessionRecord) ([]SessionRecord, HistoryEdit, error) {
+		if err := checkSession(history, session); err != nil {
+			return nil, HistoryEdit{}, err
+		}
+		history = insertByStart(history, session)
+		return history, HistoryEdit{Action: HistoryEditAdd, After: &session}, nil
+	})
+	return session, err
+}
+
+// UpdateSession replaces the session with the same ID. The new version must
+// not overlap another session on the same timer.
+func (hm *HistoryManager) UpdateSession(session SessionRecord) error {
+	return hm.edit(func(history []SessionRecord) ([]SessionRecord, HistoryEdit, error) {
+		i, err := findSession(history, session.ID)
+		if err != nil {
+			return nil, HistoryEdit{}, err
+		}
+		if err := checkSession(history, session); err != nil {
+			return nil, HistoryEdit{}, err
+		}
+		before := history[i]
+		history[i] = session
+		return history, HistoryEdit{Action: HistoryEditUpdate, Before: &before, After: &session}, nil
+	})
+}
+
+// RemoveSession removes the session whose ID is id, or starts with it, and
+// returns it.
+func (hm *HistoryManager) RemoveSession(id string) (SessionRecord, error) {
+	var removed SessionRecord
+	err := hm.edit(func(history []SessionRecord) ([]SessionRecord, HistoryEdit, error) {
+		i, err := findSession(history, id)
+		if err != nil {
+			return nil, HistoryEdit{}, err
+		}
+		removed = history[i]
+		history = append(history[:i], history[i+1:]...)
+		return history, HistoryEdit{Action: HistoryEditRemove, Before: &removed}, nil
+	})
+	return removed, err
+}
+
+// UndoEdit reverts the last change made by hand and returns it. Sessions
+// recorded since are kept. It can be undone only once.
+func (hm *HistoryManager) UndoEdit() (HistoryEdit, error) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	var last HistoryEdit
+	err := withFileLock(hm.historyFile, func() error {
+		data, err := os.ReadFile(hm.undoFile())
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no history edit to undo")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read undo file: %w", err)
+		}
+		if err := json.Unmarshal(data, &last); err != nil {
+			return fmt.Errorf("failed to parse undo file: %w", err)
+		}
+
+		history, _, err := hm.loadHistory()
+		if err != nil {
+			return fmt.Errorf("failed to load history: %w", err)
+		}
+		if last.After != nil {
+			if i, err := findSession(history, last.After.ID); err == nil {
+				history = append(history[:i], history[i+1:]...)
+			}
+		}
+		if last.Before != nil {
+			history = insertByStart(history, *last.Before)
+		}
+
+		if err := hm.backup(); err != nil {
+			return err
+		}
+		if err := hm.saveHistory(history); err != nil {
+			return err
+		}
+		if err := os.Remove(hm.undoFile()); err != nil {
+			return fmt.Errorf("failed to remove undo file: %w", err)
+		}
+		return nil
+	})
+	return last, err
+}
+
+// BackupFile returns the path of the copy of the history file taken before
+// the last change made by hand.
+func (hm *HistoryManager) BackupFile() string {
+	return hm.historyFile + ".bak"
+}
+
+// undoFile returns the path the last change made by hand is kept at.
+func (hm *HistoryManager) undoFile() string {
+	return hm.historyFile + ".undo"
+}
+
+// edit changes history by hand under the file lock: change returns the new
+// history and what it did. The history file is backed up first, and the
+// change is kept so that it can be undone.
+func (hm *HistoryManager) edit(change func([]SessionRecord) ([]SessionRecord, HistoryEdit, error)) error {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	return withFileLock(hm.historyFile, func() error {
+		if err := hm.migrateLegacy(); err != nil {
+			return err
+		}
+
+		history, _, err := hm.loadHistory()
+		if err != nil {
+			return fmt.Errorf("failed to load history: %w", err)
+		}
+		assignMissingIDs(history)
+
+		history, last, err := change(history)
+		if err != nil {
+			return err
+		}
+
+		if err := hm.backup(); err != nil {
+			return err
+		}
+		if err := hm.saveHistory(history); err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(last)
+		if err != nil {
+			return fmt.Errorf("failed to marshal history edit: %w", err)
+		}
+		if err := writeFileAtomic(hm.undoFile(), data, 0600); err != nil {
+			return fmt.Errorf("failed to write undo file: %w", err)
+		}
+		return nil
+	})
+}
+
+// backup copies the history file to the backup file. The caller must hold
+// the file lock.
+func (hm *HistoryManager) backup() error {
+	data, err := os.ReadFile(hm.historyFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read history file: %w", err)
+	}
+	if err := writeFileAtomic(hm.BackupFile(), data, 0600); err != nil {
+		return fmt.Errorf("failed to back up history file: %w", err)
+	}
+	return nil
+}
+
+// assignMissingIDs gives an ID to every session recorded before sessions had
+// one, so that it can be edited. It reports whether any was missing.
+func assignMissingIDs(history []SessionRecord) bool {
+	assigned := false
+	for i := range history {
+		if history[i].ID == "" {
+			history[i].ID = newSessionID()
+			assigned = true
+		}
+	}
+	return assigned
+}
+
+// findSession returns the index of the session whose ID is id, or the only
+// one starting with it.
+func findSession(history []SessionRecord, id string) (int, error) {
+	if id == "" {
+		return -1, fmt.Errorf("no session ID given")
+	}
+	found := -1
+	for i, session := range history {
+		if session.ID == id {
+			return i, nil
+		}
+		if strings.HasPrefix(session.ID, id) {
+			if found >= 0 {
+				return -1, fmt.Errorf("session ID %q is ambiguous", id)
+			}
+			found = i
+		}
+	}
+	if found < 0 {
+		return -1, fmt.Errorf("no session with ID %q", id)
+	}
+	return found, nil
+}
+
+// checkSession checks that session ends after it starts and does not
+// overlap another session on the same timer.
+func checkSession(history []SessionRecord, session SessionRecord) error {
+	if !session.EndTime.After(session.StartTime) {
+		return fmt.Errorf("session must end after it starts")
+	}
+	for _, other := range history {
+		if other.ID == session.ID || other.TimerName != session.TimerName {
+			continue
+		}
+		if session.StartTime.Before(other.EndTime) && other.StartTime.Before(session.EndTime) {
+			return &OverlapError{Session: other}
+		}
+	}
+	return nil
+}
+
+// insertByStart inserts session before the first recorded session that
+// started after it.
+func insertByStart(history []SessionRecord, session SessionRecord) []SessionRecord {
+	i := len(history)
+	for j, other := range history {
+		if other.StartTime.After(session.StartTime) {
+			i = j
+			break
+		}
+	}
+	history = append(history, SessionRecord{})
+	copy(history[i+1:], history[i:])
+	history[i] = session
+	return history
+}
@@ -0,0 +1,104 @@
+package timer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHistoryManagerEditsByHand(t *testing.T) {
+	hm := &HistoryManager{historyFile: filepath.Join(t.TempDir(), "history.jsonl")}
+	start := testStart
+	work := func(from time.Time, length time.Duration) SessionRecord {
+		return SessionRecord{ID: newSessionID(), Type: SessionTypeWork, Duration: length, StartTime: from, EndTime: from.Add(length), Completed: true}
+	}
+
+	if err := hm.AddSession(work(start, 25*time.Minute)); err != nil {
+		t.Fatalf("failed to add session: %v", err)
+	}
+	if err := hm.AddSession(work(start.Add(2*time.Hour), 25*time.Minute)); err != nil {
+		t.Fatalf("failed to add session: %v", err)
+	}
+
+	history, err := hm.GetAllSessions()
+	if err != nil || len(history) != 2 {
+		t.Fatalf("expected two sessions, got %+v (%v)", history, err)
+	}
+
+	// A session done away from the computer fits between them
+	added, err := hm.InsertSession(work(start.Add(time.Hour), 25*time.Minute))
+	if err != nil {
+		t.Fatalf("failed to insert session: %v", err)
+	}
+	var overlap *OverlapError
+	if _, err := hm.InsertSession(work(start.Add(10*time.Minute), 25*time.Minute)); !errors.As(err, &overlap) || overlap.Session.ID != history[1].ID {
+		t.Errorf("expected an overlap with the first session, got %v", err)
+	}
+	if history, _ := hm.GetAllSessions(); history[1].ID != added.ID {
+		t.Errorf("expected the added session to be kept in order of start time, got %+v", history)
+	}
+
+	// Moving a session onto another is refused
+	edited := added
+	edited.Retime(start.Add(110*time.Minute), start.Add(130*time.Minute))
+	if err := hm.UpdateSession(edited); err == nil {
+		t.Errorf("expected an edit that overlaps to be refused")
+	}
+	edited.Retime(start.Add(time.Hour), start.Add(90*time.Minute))
+	if err := hm.UpdateSession(edited); err != nil {
+		t.Fatalf("failed to edit session: %v", err)
+	}
+	if found, err := hm.FindSession(added.ID[:6]); err != nil || found.ActiveDuration() != 30*time.Minute {
+		t.Errorf("expected the edited session by its ID prefix, got %+v (%v)", found, err)
+	}
+
+	// Removing can be undone, keeping sessions recorded since
+	if _, err := hm.RemoveSession(added.ID); err != nil {
+		t.Fatalf("failed to remove session: %v", err)
+	}
+	if _, err := os.Stat(hm.BackupFile()); err != nil {
+		t.Errorf("expected the prior history to be backed up: %v", err)
+	}
+	if err := hm.AddSession(work(start.Add(3*time.Hour), 25*time.Minute)); err != nil {
+		t.Fatalf("failed to add session: %v", err)
+	}
+	last, err := hm.UndoEdit()
+	if err != nil || last.Action != HistoryEditRemove {
+		t.Fatalf("failed to undo removal: %+v (%v)", last, err)
+	}
+	if history, _ := hm.GetAllSessions(); len(history) != 4 {
+		t.Errorf("expected the removed session back alongside the new one, got %d sessions", len(history))
+	}
+	if _, err := hm.UndoEdit(); err == nil {
+		t.Errorf("expected nothing left to undo")
+	}
+}
+
+func TestSessionRecordRetime(t *testing.T) {
+	start := time.Date(2025, 1, 6, 22, 0, 0, 0, time.UTC)
+	session := SessionRecord{
+		Duration:  25 * time.Minute,
+		StartTime: start,
+		EndTime:   start.Add(10 * time.Hour),
+		Overtime:  10*time.Hour - 35*time.Minute,
+		Pauses: []PauseInterval{
+			{Start: start.Add(10 * time.Minute), End: start.Add(20 * time.Minute)},
+			{Start: start.Add(30 * time.Minute), End: start.Add(2 * time.Hour)},
+			{Start: start.Add(5 * time.Hour), End: start.Add(6 * time.Hour)},
+		},
+	}
+
+	// The timer was forgotten overnight; it really ended after 40 minutes
+	session.Retime(start, start.Add(40*time.Minute))
+	if len(session.Pauses) != 2 || !session.Pauses[1].End.Equal(start.Add(40*time.Minute)) {
+		t.Errorf("expected pauses to be cut at the new end, got %+v", session.Pauses)
+	}
+	if active := session.ActiveDuration(); active != 20*time.Minute {
+		t.Errorf("expected 20m active, got %v", active)
+	}
+	if session.Overtime != 0 {
+		t.Errorf("expected no overtime left, got %v", session.Overtime)
+	}
+}
@@ -0,0 +1,316 @@
+package timer
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/rsmacapinlac/pomodux/internal/logger"
+	"github.com/rsmacapinlac/pomodux/internal/theme"
+)
+
+// Controller is the set of timer operations driven by the interactive display.
+// It is implemented for in-process timers by StartPersistent and by the daemon
+// client for sessions owned by the background daemon.
+type Controller interface {
+	Pause() error
+	Resume() error
+	Stop() error
+	Skip() error
+	Extend(by time.Duration) error
+	AddNote(text string) error
+	Snapshot() (Snapshot, error)
+}
+
+// ExtendStep is how much time the extend key adds to a session.
+const ExtendStep = 5 * time.Minute
+
+// localController adapts an in-process Timer to the Controller interface.
+type localController struct {
+	timer *Timer
+}
+
+func (c localController) Pause() error  { return c.timer.Pause() }
+func (c localController) Resume() error { return c.timer.Resume() }
+func (c localController) Stop() error   { return c.timer.Stop() }
+func (c localController) Skip() error   { return c.timer.Skip() }
+
+func (c localController) Extend(by time.Duration) error { return c.timer.Extend(by) }
+func (c localController) AddNote(text string) error     { return c.timer.AddNote(text) }
+
+func (c localController) Snapshot() (Snapshot, error) {
+	return c.timer.Snapshot(), nil
+}
+
+// RunInteractive shows live progress for the session behind ctrl, drawn with
+// th, and handles keypress controls, as bound in keys, until the session
+// completes or is stopped.
+func RunInteractive(ctrl Controller, keys KeyMap, th *theme.Theme) error {
+	snapshot, err := ctrl.Snapshot()
+	if err != nil {
+		return fmt.Errorf("failed to get timer status: %w", err)
+	}
+
+	if snapshot.Stopwatch {
+		fmt.Println("Stopwatch started")
+	} else {
+		fmt.Printf("Timer started for %v\n", snapshot.Duration)
+	}
+	fmt.Printf("Session type: %s%s\n", th.Icon(string(snapshot.SessionType)), th.Paint(string(snapshot.SessionType), string(snapshot.SessionType)))
+	if snapshot.Task != "" {
+		fmt.Printf("Task: %s\n", snapshot.Task)
+	}
+	fmt.Println(keys.helpLine())
+
+	oldState, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return fmt.Errorf("failed to set terminal raw mode: %w", err)
+	}
+	defer term.Restore(int(os.Stdin.Fd()), oldState)
+
+	sessionType := snapshot.SessionType
+	inOvertime := false
+	compact := false
+
+	keyChan := make(chan byte, 1)
+	stopKeys := ReadKeys(os.Stdin, keyChan)
+	defer stopKeys()
+
+	interruptChan := make(chan os.Signal, 1)
+	signal.Notify(interruptChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interruptChan)
+
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-interruptChan:
+			clearLine()
+			fmt.Println("Timer stopped by user (signal).")
+			if err := ctrl.Stop(); err != nil {
+				logger.Warn("Failed to stop timer", map[string]interface{}{"error": err.Error()})
+			}
+			return nil
+		case key := <-keyChan:
+			action := keys[key]
+			if key == 3 { // Ctrl+C
+				action = KeyStop
+			}
+			switch action {
+			// The session is already started, so the start key stops it
+			case KeyStop, KeyStart:
+				clearLine()
+				fmt.Println("Timer stopped.")
+				if err := ctrl.Stop(); err != nil {
+					logger.Warn("Failed to stop timer", map[string]interface{}{"error": err.Error()})
+				}
+				return nil
+			case KeyPause:
+				if err := ctrl.Pause(); err != nil {
+					logger.Warn("Failed to pause timer", map[string]interface{}{"error": err.Error()})
+				}
+			case KeyResume:
+				if err := ctrl.Resume(); err != nil {
+					logger.Warn("Failed to resume timer", map[string]interface{}{"error": err.Error()})
+				} else {
+					fmt.Print("\r" + th.Icon(theme.IconResumed) + th.Paint(string(sessionType), "RESUMED") + strings.Repeat(" ", 50))
+				}
+			case KeySkip:
+				if err := ctrl.Skip(); err != nil {
+					logger.Warn("Failed to skip session", map[string]interface{}{"error": err.Error()})
+				}
+			case KeyExtend:
+				if err := ctrl.Extend(ExtendStep); err != nil {
+					logger.Warn("Failed to extend session", map[string]interface{}{"error": err.Error()})
+				}
+			case KeyNote:
+				if text, ok := readNote(keyChan); ok {
+					if err := ctrl.AddNote(text); err != nil {
+						logger.Warn("Failed to add note", map[string]interface{}{"error": err.Error()})
+					}
+				}
+			case KeyToggleDisplay:
+				compact = !compact
+				clearLine()
+			}
+		case <-ticker.C:
+		}
+
+		// The display is always re-synced from the timer so that changes made
+		// by other commands are picked up
+		snapshot, err = ctrl.Snapshot()
+		if err != nil {
+			clearLine()
+			return fmt.Errorf("failed to get timer status: %w", err)
+		}
+
+		switch snapshot.Status {
+		case StatusCompleted:
+			clearLine()
+			fmt.Println("Timer completed! Session recorded.")
+			return nil
+		case StatusIdle:
+			clearLine()
+			fmt.Println("Timer stopped externally.")
+			return nil
+		case StatusPaused:
+			renderPaused(th, keys)
+		case StatusRunning:
+			// A different session type means the cycle moved on to the next session
+			if snapshot.SessionType != sessionType {
+				clearLine()
+				fmt.Printf("%s session ended. Starting %s session for %v\n", sessionType, snapshot.SessionType, snapshot.Duration)
+				sessionType = snapshot.SessionType
+				inOvertime = false
+			}
+			if snapshot.Stopwatch {
+				renderStopwatch(th, snapshot)
+				continue
+			}
+			if snapshot.OvertimeMode && snapshot.Elapsed >= snapshot.Duration {
+				if !inOvertime {
+					clearLine()
+					fmt.Printf("Planned %v reached. Counting overtime until stopped.\n", snapshot.Duration)
+					inOvertime = true
+				}
+				renderOvertime(th, snapshot)
+				continue
+			}
+			if compact {
+				renderCompact(th, snapshot)
+				continue
+			}
+			renderProgress(th, snapshot)
+		}
+	}
+}
+
+// keyPollInterval is how long ReadKeys waits for input before checking
+// whether it has been stopped.
+const keyPollInterval = 100 * time.Millisecond
+
+// ReadKeys forwards single keypresses from f to keys until f returns an error
+// or the returned function is called. That function waits for reading to
+// stop, so no input typed afterwards is taken.
+func ReadKeys(f *os.File, keys chan<- byte) (stop func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		buf := make([]byte, 16)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			ready, err := waitReadable(f, keyPollInterval)
+			if err != nil {
+				return
+			}
+			if !ready {
+				continue
+			}
+			n, err := f.Read(buf)
+			for _, b := range buf[:n] {
+				select {
+				case keys <- b:
+				case <-done:
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// renderProgress draws the single-line progress display for a running session.
+func renderProgress(th *theme.Theme, snapshot Snapshot) {
+	sessionType := string(snapshot.SessionType)
+	progressBar := th.ProgressBar(snapshot.Progress, 30, sessionType)
+	percentage := int(snapshot.Progress * 100)
+	fmt.Printf("\r%s %3d%% %s | %s%s",
+		progressBar,
+		percentage,
+		formatDuration(snapshot.Remaining),
+		th.Icon(sessionType),
+		th.Paint(sessionType, sessionType))
+	logger.Debug("Timer progress", map[string]interface{}{"progress": snapshot.Progress, "remaining": snapshot.Remaining, "elapsed": snapshot.Elapsed})
+}
+
+// renderCompact draws the short single-line display toggled by the display key.
+func renderCompact(th *theme.Theme, snapshot Snapshot) {
+	sessionType := string(snapshot.SessionType)
+	fmt.Printf("\r%s left | %s%s", formatDuration(snapshot.Remaining), th.Icon(sessionType), th.Paint(sessionType, sessionType))
+}
+
+// renderPaused draws the single-line display for a paused session.
+func renderPaused(th *theme.Theme, keys KeyMap) {
+	line := th.Icon(theme.IconPaused) + th.Paint(theme.RolePaused, "PAUSED")
+	if key, ok := keys.KeyFor(KeyResume); ok {
+		line += fmt.Sprintf(" - Press '%c' to resume", key)
+	}
+	fmt.Print("\r" + line + strings.Repeat(" ", 50))
+}
+
+// readNote reads a line of text typed into the raw terminal. It returns false
+// if the note was abandoned with Escape.
+func readNote(keys <-chan byte) (string, bool) {
+	clearLine()
+	fmt.Print("Note (Enter to save, Esc to cancel): ")
+	var text []byte
+	for key := range keys {
+		switch key {
+		case '\r', '\n':
+			clearLine()
+			return string(text), true
+		case 27, 3: // Escape, Ctrl+C
+			clearLine()
+			return "", false
+		case 127, 8: // Backspace
+			if len(text) > 0 {
+				text = text[:len(text)-1]
+				fmt.Print("\b \b")
+			}
+		default:
+			if key >= ' ' {
+				text = append(text, key)
+				fmt.Printf("%c", key)
+			}
+		}
+	}
+	return "", false
+}
+
+// renderOvertime draws the single-line display for a session counting past its planned end.
+func renderOvertime(th *theme.Theme, snapshot Snapshot) {
+	sessionType := string(snapshot.SessionType)
+	fmt.Printf("\r%s %s+%s overtime | %s",
+		th.ProgressBar(1, 30, sessionType),
+		th.Icon(theme.IconOvertime),
+		formatDuration(snapshot.Overtime),
+		th.Paint(sessionType, sessionType))
+}
+
+// renderStopwatch draws the single-line display for an open-ended session.
+func renderStopwatch(th *theme.Theme, snapshot Snapshot) {
+	sessionType := string(snapshot.SessionType)
+	fmt.Printf("\r%s%s elapsed | %s", th.Icon(theme.IconStopwatch), formatDuration(snapshot.Elapsed), th.Paint(sessionType, sessionType))
+}
+
+// clearLine erases the current progress line.
+func clearLine() {
+	fmt.Print("\r" + strings.Repeat(" ", 120) + "\r")
+}
@@ -0,0 +1,79 @@
+package timer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// KeyAction is an action the interactive timer performs on a keypress.
+type KeyAction string
+
+const (
+	KeyStart         KeyAction = "start"
+	KeyStop          KeyAction = "stop"
+	KeyPause         KeyAction = "pause"
+	KeyResume        KeyAction = "resume"
+	KeySkip          KeyAction = "skip"
+	KeyExtend        KeyAction = "extend"
+	KeyNote          KeyAction = "note"
+	KeyToggleDisplay KeyAction = "toggle_display"
+)
+
+// KeyMap maps keypresses to the actions they trigger.
+type KeyMap map[byte]KeyAction
+
+// NewKeyMap builds a key map from action to key bindings, such as the
+// tui.key_bindings configuration. Bindings that are not a single character
+// are ignored.
+func NewKeyMap(bindings map[string]string) KeyMap {
+	keys := make(KeyMap, len(bindings))
+	for action, key := range bindings {
+		if len(key) == 1 {
+			keys[key[0]] = KeyAction(action)
+		}
+	}
+	return keys
+}
+
+// KeyFor returns the key bound to action.
+func (m KeyMap) KeyFor(action KeyAction) (byte, bool) {
+	for key, bound := range m {
+		if bound == action {
+			return key, true
+		}
+	}
+	return 0, false
+}
+
+// helpLine describes the keys the interactive timer responds to.
+func (m KeyMap) helpLine() string {
+	descriptions := []struct {
+		action KeyAction
+		text   string
+	}{
+		{KeyPause, "pause"},
+		{KeyResume, "resume"},
+		{KeyStop, "stop"},
+		{KeySkip, "skip"},
+		{KeyExtend, fmt.Sprintf("add %v", ExtendStep)},
+		{KeyNote, "add a note"},
+		{KeyToggleDisplay, "toggle the display"},
+	}
+
+	var parts []string
+	for _, d := range descriptions {
+		var keys []string
+		if key, ok := m.KeyFor(d.action); ok {
+			keys = append(keys, fmt.Sprintf("'%c'", key))
+		}
+		// The start key stops the session the interactive timer shows
+		if key, ok := m.KeyFor(KeyStart); ok && d.action == KeyStop {
+			keys = append(keys, fmt.Sprintf("'%c'", key))
+		}
+		if len(keys) > 0 {
+			parts = append(parts, strings.Join(keys, "/")+" to "+d.text)
+		}
+	}
+	parts = append(parts, "Ctrl+C to exit")
+	return "Press " + strings.Join(parts, ", ") + "."
+}
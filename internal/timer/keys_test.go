@@ -0,0 +1,64 @@
+package timer
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewKeyMap(t *testing.T) {
+	keys := NewKeyMap(map[string]string{"pause": "x", "stop": "Q", "skip": "too long"})
+
+	if keys['x'] != KeyPause || keys['Q'] != KeyStop {
+		t.Errorf("expected configured keys to be mapped, got %v", keys)
+	}
+	if _, ok := keys.KeyFor(KeySkip); ok {
+		t.Errorf("expected a binding longer than one key to be ignored")
+	}
+}
+
+func TestKeyMapHelpLineShowsBindings(t *testing.T) {
+	help := NewKeyMap(map[string]string{"pause": "x", "stop": "Q", "start": "s", "extend": "+"}).helpLine()
+
+	for _, want := range []string{"'x' to pause", "'Q'/'s' to stop", "'+' to add 5m0s", "Ctrl+C to exit"} {
+		if !strings.Contains(help, want) {
+			t.Errorf("expected help line to contain %q, got %q", want, help)
+		}
+	}
+	if strings.Contains(help, "resume") {
+		t.Errorf("expected unbound actions to be left out, got %q", help)
+	}
+}
+
+func TestReadKeysStops(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pipes cannot be waited on like the console")
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	keys := make(chan byte, 4)
+	stop := ReadKeys(r, keys)
+	w.Write([]byte("p"))
+	select {
+	case key := <-keys:
+		if key != 'p' {
+			t.Errorf("expected 'p', got %q", key)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the keypress to be read")
+	}
+
+	stop()
+	w.Write([]byte("q"))
+	buf := make([]byte, 1)
+	if _, err := r.Read(buf); err != nil || buf[0] != 'q' {
+		t.Errorf("expected input after stopping to be left unread, got %q (%v)", buf, err)
+	}
+}
@@ -0,0 +1,138 @@
+package timer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// heartbeatInterval is how often an owner of the timer state records that it
+// is still alive, which bounds the time counted for a session after a crash.
+const heartbeatInterval = 30 * time.Second
+
+// staleAfter is how long after its last heartbeat an owner is taken to be
+// gone, even if a process with its PID is running.
+const staleAfter = 2 * heartbeatInterval
+
+// Lock records which process owns the persisted timer state. The owner
+// refreshes Heartbeat while it runs, so after a crash it tells us roughly
+// when the owner was last alive.
+type Lock struct {
+	PID       int       `json:"pid"`
+	Heartbeat time.Time `json:"heartbeat"`
+}
+
+// ownerFile returns the path of the file recording the owner of the state.
+func (sm *StateManager) ownerFile() string {
+	return filepath.Join(filepath.Dir(sm.stateFile), "timer.pid")
+}
+
+// AcquireLock makes the current process the owner of the timer state. It
+// returns the lock left behind by a previous owner that exited without
+// releasing it, or nil if there was none. Every timer in the state file shares
+// the lock, so a timer created after the process took ownership gets the lock
+// found when it did. It fails if the state is owned by another pomodux
+// process that is still running and has kept its heartbeat fresh.
+func (sm *StateManager) AcquireLock(now time.Time) (*Lock, error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	var previous *Lock
+	err := withFileLock(sm.ownerFile(), func() error {
+		var err error
+		previous, err = sm.readLock()
+		if err != nil {
+			return err
+		}
+		if previous != nil {
+			if previous.PID == os.Getpid() {
+				previous = sm.previous
+			} else if previous.alive(now) {
+				return fmt.Errorf("timer state is owned by running process %d", previous.PID)
+			}
+		}
+		return sm.writeLock(Lock{PID: os.Getpid(), Heartbeat: now})
+	})
+	if err != nil {
+		return nil, err
+	}
+	sm.previous = previous
+	return previous, nil
+}
+
+// alive reports whether the owner holding the lock is still running. After a
+// reboot its PID may belong to an unrelated process, so the owner must also
+// be running pomodux and have refreshed its heartbeat recently.
+func (lock *Lock) alive(now time.Time) bool {
+	return now.Sub(lock.Heartbeat) <= staleAfter && processAlive(lock.PID) && processIsPomodux(lock.PID)
+}
+
+// RefreshLock updates the heartbeat of the lock held by the current process.
+// It fails if another process has taken the lock over.
+func (sm *StateManager) RefreshLock(now time.Time) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	return withFileLock(sm.ownerFile(), func() error {
+		lock, err := sm.readLock()
+		if err != nil {
+			return err
+		}
+		if lock != nil && lock.PID != os.Getpid() {
+			return fmt.Errorf("timer state was taken over by process %d", lock.PID)
+		}
+		return sm.writeLock(Lock{PID: os.Getpid(), Heartbeat: now})
+	})
+}
+
+// ReleaseLock removes the lock if it is held by the current process.
+func (sm *StateManager) ReleaseLock() error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	return withFileLock(sm.ownerFile(), func() error {
+		lock, err := sm.readLock()
+		if err != nil || lock == nil || lock.PID != os.Getpid() {
+			return err
+		}
+		if err := os.Remove(sm.ownerFile()); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove owner file: %w", err)
+		}
+		return nil
+	})
+}
+
+// readLock reads the owner file, returning nil if there is none.
+func (sm *StateManager) readLock() (*Lock, error) {
+	data, err := os.ReadFile(sm.ownerFile())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read owner file: %w", err)
+	}
+
+	var lock Lock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		// An unreadable lock cannot belong to a live owner
+		return &Lock{}, nil
+	}
+	return &lock, nil
+}
+
+// writeLock writes the owner file. The caller must hold its file lock.
+func (sm *StateManager) writeLock(lock Lock) error {
+	if err := os.MkdirAll(filepath.Dir(sm.stateFile), 0750); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := json.Marshal(lock)
+	if err != nil {
+		return fmt.Errorf("failed to marshal lock: %w", err)
+	}
+	if err := writeFileAtomic(sm.ownerFile(), data, 0600); err != nil {
+		return fmt.Errorf("failed to write owner file: %w", err)
+	}
+	return nil
+}
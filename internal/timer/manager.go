@@ -2,6 +2,9 @@ package timer
 
 import (
 	"sync"
+
+	"github.com/rsmacapinlac/pomodux/internal/logger"
+	"github.com/rsmacapinlac/pomodux/internal/plugin"
 )
 
 // Global timer instance
@@ -10,39 +13,109 @@ var (
 	timerOnce            sync.Once
 	globalStateManager   *StateManager
 	globalHistoryManager *HistoryManager
+	globalPluginManager  *plugin.PluginManager
+	globalPluginsDir     string
 )
 
+// EnableGlobalPlugins configures the global timer to load Lua plugins from
+// pluginsDir when it is created. It must be called before GetGlobalTimer.
+func EnableGlobalPlugins(pluginsDir string) {
+	globalPluginsDir = pluginsDir
+}
+
 // GetGlobalTimer returns the global timer instance.
 // This ensures all CLI commands use the same timer.
 func GetGlobalTimer() *Timer {
 	timerOnce.Do(func() {
-		// Create state manager
-		stateManager, err := NewStateManager()
-		if err != nil {
-			// If we can't create state manager, create timer without persistence
-			globalTimer = NewTimer()
-			return
-		}
-		globalStateManager = stateManager
-
-		// Create history manager
-		historyManager, err := NewHistoryManager()
-		if err != nil {
-			// If we can't create history manager, create timer without history
-			globalTimer = NewTimerWithManagers(stateManager, nil)
-			return
-		}
-		globalHistoryManager = historyManager
+		globalTimer = newGlobalTimer()
 
-		// Create timer with both managers
-		globalTimer = NewTimerWithManagers(stateManager, historyManager)
+		if globalPluginsDir != "" {
+			globalPluginManager = loadGlobalPlugins(globalPluginsDir)
+			globalTimer.SetPluginManager(globalPluginManager)
+		}
 	})
 
 	return globalTimer
 }
 
+// newGlobalTimer creates the global timer with whatever persistence is available.
+func newGlobalTimer() *Timer {
+	// Create state manager
+	stateManager, err := NewStateManager()
+	if err != nil {
+		// If we can't create state manager, create timer without persistence
+		return NewTimer()
+	}
+	globalStateManager = stateManager
+
+	// Create history manager
+	historyManager, err := NewHistoryManager()
+	if err != nil {
+		// If we can't create history manager, create timer without history
+		return NewTimerWithManagers(stateManager, nil, RealClock())
+	}
+	globalHistoryManager = historyManager
+
+	// Create timer with both managers
+	return NewTimerWithManagers(stateManager, historyManager, RealClock())
+}
+
+// NewNamedTimer creates a timer stored under name in the same state file as
+// the global timer, sharing its history and plugins. GetGlobalTimer must
+// have been called first.
+func NewNamedTimer(name string) *Timer {
+	if globalStateManager == nil {
+		t := NewTimer()
+		t.name = name
+		return t
+	}
+
+	var history HistoryStore
+	if globalHistoryManager != nil {
+		history = globalHistoryManager
+	}
+	t := NewTimerWithManagers(globalStateManager.ForName(name), history, RealClock())
+	if globalPluginManager != nil {
+		t.SetPluginManager(globalPluginManager)
+	}
+	return t
+}
+
+// NamedTimerNames returns the names of the named timers with sessions in the
+// global state file.
+func NamedTimerNames() []string {
+	if globalStateManager == nil {
+		return nil
+	}
+	names, err := globalStateManager.Names()
+	if err != nil {
+		logger.Warn("Failed to read named timers", map[string]interface{}{"error": err.Error()})
+		return nil
+	}
+	return names
+}
+
+// loadGlobalPlugins creates a plugin manager and loads every plugin in pluginsDir.
+// Plugins that fail to load are logged and skipped.
+func loadGlobalPlugins(pluginsDir string) *plugin.PluginManager {
+	pm := plugin.NewPluginManager(pluginsDir)
+	if err := pm.LoadPlugins(); err != nil {
+		logger.Warn("Failed to load plugins", map[string]interface{}{"plugins_dir": pluginsDir, "error": err.Error()})
+	}
+	logger.Debug("Plugins loaded", map[string]interface{}{"plugins_dir": pluginsDir, "count": len(pm.ListPlugins())})
+	return pm
+}
+
 // ShutdownGlobalTimer gracefully shuts down the global timer.
 // This should be called when the application exits.
 func ShutdownGlobalTimer() {
-	// Nothing to do for the simplified timer
+	// Hand the timer state over cleanly so the next owner does not treat it as interrupted
+	if globalTimer != nil {
+		globalTimer.Close()
+	}
+
+	// Deliver any queued plugin events before the process exits
+	if globalPluginManager != nil {
+		globalPluginManager.Shutdown()
+	}
 }
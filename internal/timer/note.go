@@ -0,0 +1,43 @@
+package timer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rsmacapinlac/pomodux/internal/logger"
+)
+
+// Note is a remark added to a session while it was running.
+type Note struct {
+	Time time.Time `json:"time"`
+	Text string    `json:"text"`
+}
+
+// AddNote adds a note to the current session. Notes are recorded in history
+// with the session.
+func (t *Timer) AddNote(text string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.checkCompletionLocked()
+	if t.status != StatusRunning && t.status != StatusPaused {
+		return fmt.Errorf("timer not running")
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return fmt.Errorf("note is empty")
+	}
+
+	t.notes = append(t.notes, Note{Time: t.clock.Now(), Text: text})
+
+	// Save state
+	if t.stateManager != nil {
+		if err := t.stateManager.SaveState(t); err != nil {
+			logger.Warn("Failed to save timer state", map[string]interface{}{"error": err.Error()})
+		}
+	}
+
+	logger.Info("Note added", map[string]interface{}{"session_type": t.sessionType})
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package timer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimerNotesAreRecordedWithSession(t *testing.T) {
+	timer, clock := newTestTimer(t)
+	history := timer.historyManager
+
+	if err := timer.AddNote("too early"); err == nil {
+		t.Errorf("expected adding a note without a session to fail")
+	}
+	if err := timer.Start(25 * time.Minute); err != nil {
+		t.Fatalf("failed to start timer: %v", err)
+	}
+	clock.Advance(10 * time.Minute)
+	if err := timer.AddNote("  found the bug  "); err != nil {
+		t.Fatalf("failed to add note: %v", err)
+	}
+	if err := timer.AddNote(" "); err == nil {
+		t.Errorf("expected an empty note to be rejected")
+	}
+	clock.Advance(15 * time.Minute)
+	timer.GetStatus()
+
+	last, err := history.GetLastSession()
+	if err != nil {
+		t.Fatalf("failed to read history: %v", err)
+	}
+	if len(last.Notes) != 1 || last.Notes[0].Text != "found the bug" || !last.Notes[0].Time.Equal(last.StartTime.Add(10*time.Minute)) {
+		t.Errorf("expected the note to be recorded with the session, got %+v", last.Notes)
+	}
+}
@@ -0,0 +1,41 @@
+package timer
+
+import (
+	"time"
+
+	"github.com/rsmacapinlac/pomodux/internal/logger"
+)
+
+// reachPlanLocked handles an overtime session passing its planned duration.
+// The session is recorded and plugins are told it completed, but it keeps
+// running until it is stopped. The caller must hold t.mu.
+func (t *Timer) reachPlanLocked() {
+	if t.planReached {
+		return
+	}
+	t.planReached = true
+	t.cycle.advance(t.sessionType)
+
+	// Save state
+	if t.stateManager != nil {
+		if err := t.stateManager.SaveState(t); err != nil {
+			logger.Warn("Failed to save timer state", map[string]interface{}{"error": err.Error()})
+		}
+	}
+
+	// Record the session now so it is kept even if it is never stopped
+	t.recordSessionLocked(true, false)
+
+	t.emitCompletedLocked()
+
+	logger.Info("Timer reached planned duration, counting overtime", map[string]interface{}{"session_type": t.sessionType, "duration": t.duration})
+}
+
+// overtimeLocked returns how long the session has run past its planned
+// duration. The caller must hold t.mu.
+func (t *Timer) overtimeLocked() time.Duration {
+	if !t.planReached {
+		return 0
+	}
+	return t.elapsedLocked() - t.duration
+}
@@ -0,0 +1,73 @@
+package timer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimerOvertimeKeepsCountingUntilStopped(t *testing.T) {
+	timer, clock := newTestTimer(t)
+	history := timer.historyManager
+
+	if err := timer.StartSession(25*time.Minute, SessionTypeWork, Annotation{}, true); err != nil {
+		t.Fatalf("failed to start timer: %v", err)
+	}
+	clock.Advance(30 * time.Minute)
+
+	snapshot := timer.Snapshot()
+	if snapshot.Status != StatusRunning {
+		t.Fatalf("expected overtime session to keep running, got %s", snapshot.Status)
+	}
+	if snapshot.Overtime != 5*time.Minute {
+		t.Errorf("expected 5m overtime, got %v", snapshot.Overtime)
+	}
+	if cycle := timer.GetCycle(); cycle.Pomodoros != 1 {
+		t.Errorf("expected the session to count as a pomodoro once, got %d", cycle.Pomodoros)
+	}
+	if snapshot.NextSessionType != SessionTypeBreak {
+		t.Errorf("expected a break next, got %s", snapshot.NextSessionType)
+	}
+
+	// The session is recorded as soon as it reaches its planned end
+	last, err := history.GetLastSession()
+	if err != nil {
+		t.Fatalf("failed to read history: %v", err)
+	}
+	if !last.Completed {
+		t.Errorf("expected session to be recorded as completed at its planned end")
+	}
+
+	clock.Advance(5 * time.Minute)
+	if err := timer.Stop(); err != nil {
+		t.Fatalf("failed to stop timer: %v", err)
+	}
+
+	sessions, err := history.GetAllSessions()
+	if err != nil {
+		t.Fatalf("failed to read history: %v", err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("expected a single history record, got %d", len(sessions))
+	}
+	if !sessions[0].Completed || sessions[0].Duration != 25*time.Minute || sessions[0].Overtime != 10*time.Minute {
+		t.Errorf("expected a completed 25m session with 10m overtime, got %+v", sessions[0])
+	}
+	if cycle := timer.GetCycle(); cycle.Pomodoros != 1 {
+		t.Errorf("expected stopping not to count the session again, got %d", cycle.Pomodoros)
+	}
+}
+
+func TestTimerOvertimeFromCycleConfig(t *testing.T) {
+	clock := NewFakeClock(testStart)
+	timer := NewTimerWithClock(clock)
+	timer.SetCycleConfig(CycleConfig{WorkDuration: 25 * time.Minute, BreakDuration: 5 * time.Minute, Overtime: true})
+
+	if err := timer.Start(time.Minute); err != nil {
+		t.Fatalf("failed to start timer: %v", err)
+	}
+	clock.Advance(2 * time.Minute)
+
+	if status := timer.GetStatus(); status != StatusRunning {
+		t.Errorf("expected configured overtime to keep the session running, got %s", status)
+	}
+}
@@ -0,0 +1,46 @@
+package timer
+
+import "time"
+
+// PauseInterval is a stretch of a session during which the timer was paused.
+type PauseInterval struct {
+	Start time.Time `json:"start"`
+	// End is zero while the pause is ongoing
+	End    time.Time `json:"end"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+// Duration returns how long the pause lasted.
+func (p PauseInterval) Duration() time.Duration {
+	if p.End.IsZero() {
+		return 0
+	}
+	return p.End.Sub(p.Start)
+}
+
+// closePauses returns a copy of pauses with an ongoing pause ended at end.
+func closePauses(pauses []PauseInterval, end time.Time) []PauseInterval {
+	if len(pauses) == 0 {
+		return nil
+	}
+	closed := make([]PauseInterval, len(pauses))
+	copy(closed, pauses)
+	if last := &closed[len(closed)-1]; last.End.IsZero() {
+		last.End = end
+	}
+	return closed
+}
+
+// PausedTime returns the total time the session spent paused.
+func (s SessionRecord) PausedTime() time.Duration {
+	var total time.Duration
+	for _, pause := range s.Pauses {
+		total += pause.Duration()
+	}
+	return total
+}
+
+// ActiveDuration returns how long the session actually ran, leaving out pauses.
+func (s SessionRecord) ActiveDuration() time.Duration {
+	return s.EndTime.Sub(s.StartTime) - s.PausedTime()
+}
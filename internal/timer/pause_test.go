@@ -0,0 +1,55 @@
+package timer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimerRecordsPauseIntervals(t *testing.T) {
+	start := testStart
+	timer, clock := newTestTimer(t)
+
+	if err := timer.Start(25 * time.Minute); err != nil {
+		t.Fatalf("failed to start timer: %v", err)
+	}
+	clock.Advance(5 * time.Minute)
+	if err := timer.PauseWithReason("call"); err != nil {
+		t.Fatalf("failed to pause timer: %v", err)
+	}
+	clock.Advance(10 * time.Minute)
+	if err := timer.Resume(); err != nil {
+		t.Fatalf("failed to resume timer: %v", err)
+	}
+	clock.Advance(5 * time.Minute)
+	if err := timer.Pause(); err != nil {
+		t.Fatalf("failed to pause timer: %v", err)
+	}
+	clock.Advance(2 * time.Minute)
+
+	if elapsed := timer.GetElapsed(); elapsed != 10*time.Minute {
+		t.Errorf("expected 10m elapsed, got %v", elapsed)
+	}
+	if err := timer.Stop(); err != nil {
+		t.Fatalf("failed to stop timer: %v", err)
+	}
+
+	last, err := timer.historyManager.GetLastSession()
+	if err != nil {
+		t.Fatalf("failed to read history: %v", err)
+	}
+	if !last.StartTime.Equal(start) {
+		t.Errorf("expected start time to survive pauses, got %v", last.StartTime)
+	}
+	if len(last.Pauses) != 2 {
+		t.Fatalf("expected 2 pauses, got %+v", last.Pauses)
+	}
+	if last.Pauses[0].Reason != "call" || last.Pauses[0].Duration() != 10*time.Minute {
+		t.Errorf("expected a 10m pause for a call, got %+v", last.Pauses[0])
+	}
+	if !last.Pauses[1].End.Equal(last.EndTime) {
+		t.Errorf("expected the ongoing pause to end with the session, got %+v", last.Pauses[1])
+	}
+	if paused := last.PausedTime(); paused != 12*time.Minute {
+		t.Errorf("expected 12m paused in total, got %v", paused)
+	}
+}
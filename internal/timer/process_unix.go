@@ -0,0 +1,55 @@
+//go:build !windows
+
+package timer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// processAlive reports whether a process with the given PID exists.
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	err := syscall.Kill(pid, 0)
+	if err != nil && !errors.Is(err, syscall.EPERM) {
+		return false
+	}
+	return !processZombie(pid)
+}
+
+// processZombie reports whether the process has exited but not yet been
+// reaped by its parent. It relies on /proc and reports false where that is
+// not available.
+func processZombie(pid int) bool {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return false
+	}
+	// The state follows the command name, which is wrapped in parentheses
+	end := bytes.LastIndexByte(data, ')')
+	return end >= 0 && end+2 < len(data) && data[end+2] == 'Z'
+}
+
+// processIsPomodux reports whether the process runs the same program as the
+// current process. It relies on /proc and reports true where that is not
+// available, leaving the heartbeat to tell whether the owner is gone.
+func processIsPomodux(pid int) bool {
+	exe, err := os.Readlink(fmt.Sprintf("/proc/%d/exe", pid))
+	if err != nil {
+		return true
+	}
+	self, err := os.Executable()
+	if err != nil {
+		return true
+	}
+	// The executable may have been replaced by an upgrade since it started
+	exe = strings.TrimSuffix(exe, " (deleted)")
+	return filepath.Base(exe) == filepath.Base(self)
+}
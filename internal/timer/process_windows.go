@@ -0,0 +1,26 @@
+//go:build windows
+
+package timer
+
+import "os"
+
+// processAlive reports whether a process with the given PID exists.
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	// On Windows FindProcess opens a handle to the process and fails if it does not exist
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	process.Release()
+	return true
+}
+
+// processIsPomodux reports whether the process runs the same program as the
+// current process. It is not checked on Windows, leaving the heartbeat to
+// tell whether the owner is gone.
+func processIsPomodux(pid int) bool {
+	return true
+}
@@ -0,0 +1,191 @@
+package timer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rsmacapinlac/pomodux/internal/logger"
+	"github.com/rsmacapinlac/pomodux/internal/plugin"
+)
+
+// RecoveryAction is what to do with a session interrupted by a crash or reboot.
+type RecoveryAction string
+
+const (
+	// RecoveryRecord records the interrupted session in history as not completed
+	RecoveryRecord RecoveryAction = "record"
+	// RecoveryResume continues the session from where it was interrupted
+	RecoveryResume RecoveryAction = "resume"
+	// RecoveryDiscard drops the session without recording it
+	RecoveryDiscard RecoveryAction = "discard"
+)
+
+// ParseRecoveryAction parses the name of a recovery action.
+func ParseRecoveryAction(name string) (RecoveryAction, error) {
+	switch action := RecoveryAction(name); action {
+	case RecoveryRecord, RecoveryResume, RecoveryDiscard:
+		return action, nil
+	default:
+		return "", fmt.Errorf("unknown recovery action %q (expected record, resume or discard)", name)
+	}
+}
+
+// claimState takes ownership of the persisted state while the timer is being
+// created. A running or paused session left behind by an owner that died, or
+// with no owner at all, is held as interrupted until the user decides what to
+// do with it.
+func (t *Timer) claimState() {
+	now := t.clock.Now()
+	previous, err := t.stateManager.AcquireLock(now)
+	if err != nil {
+		logger.Warn("Failed to take ownership of timer state", map[string]interface{}{"error": err.Error()})
+		return
+	}
+	t.ownsState = true
+	t.heartbeatAt = now
+
+	if t.status != StatusRunning && t.status != StatusPaused {
+		return
+	}
+
+	// The owner was last known alive at its heartbeat, so count time up to
+	// then rather than up to now. State left without an owner, as by older
+	// versions, counts no time past its last save.
+	lastSeen := t.resumedAt
+	ownerPID := 0
+	if previous != nil {
+		if previous.Heartbeat.After(lastSeen) {
+			lastSeen = previous.Heartbeat
+		}
+		ownerPID = previous.PID
+	}
+	if t.status == StatusRunning {
+		t.elapsed += lastSeen.Sub(t.resumedAt)
+		if !t.planReached && !t.stopwatchLocked() && t.elapsed > t.duration {
+			t.elapsed = t.duration
+		}
+	}
+	t.interruptedAt = lastSeen
+	t.status = StatusInterrupted
+
+	if err := t.stateManager.SaveState(t); err != nil {
+		logger.Warn("Failed to save timer state", map[string]interface{}{"error": err.Error()})
+	}
+
+	logger.Warn("Found interrupted session", map[string]interface{}{"session_type": t.sessionType, "elapsed": t.elapsed, "owner_pid": ownerPID})
+}
+
+// Recover resolves a session that was interrupted by a crash or reboot.
+func (t *Timer) Recover(action RecoveryAction) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.status != StatusInterrupted {
+		return fmt.Errorf("no interrupted session to recover")
+	}
+
+	switch action {
+	case RecoveryRecord:
+		t.recordInterruptedLocked()
+		t.status = StatusIdle
+		t.elapsed = 0
+	case RecoveryResume:
+		t.resumedAt = t.clock.Now()
+		if n := len(t.pauses); n > 0 && t.pauses[n-1].End.IsZero() {
+			// The session was paused when it was interrupted
+			t.pauses = closePauses(t.pauses, t.resumedAt)
+		} else {
+			// The time the timer was down counts as a pause
+			t.pauses = append(t.pauses, PauseInterval{Start: t.interruptedAt, End: t.resumedAt, Reason: "interrupted"})
+		}
+		t.status = StatusRunning
+	case RecoveryDiscard:
+		t.status = StatusIdle
+		t.elapsed = 0
+	default:
+		return fmt.Errorf("unknown recovery action %q", action)
+	}
+	interruptedAt := t.interruptedAt
+	t.interruptedAt = time.Time{}
+
+	// Save state
+	if t.stateManager != nil {
+		if err := t.stateManager.SaveState(t); err != nil {
+			logger.Warn("Failed to save timer state", map[string]interface{}{"error": err.Error()})
+		}
+	}
+
+	// Emit timer recovered event for plugins
+	if t.pluginManager != nil {
+		event := plugin.Event{
+			Type:      plugin.EventTimerRecovered,
+			Timestamp: t.clock.Now(),
+			Data: map[string]interface{}{
+				"session_type":   string(t.sessionType),
+				"timer_name":     t.name,
+				"task":           t.annotation.Task,
+				"tags":           t.annotation.Tags,
+				"duration":       int(t.duration.Seconds()),
+				"start_time":     t.startTime.Unix(),
+				"interrupted_at": interruptedAt.Unix(),
+				"action":         string(action),
+			},
+		}
+		t.pluginManager.EmitEvent(event)
+	}
+
+	logger.Info("Timer recovered", map[string]interface{}{"session_type": t.sessionType, "action": action})
+
+	return nil
+}
+
+// recordInterruptedLocked records the interrupted session in history, ending
+// when its owner was last seen. The caller must hold t.mu.
+func (t *Timer) recordInterruptedLocked() {
+	if t.historyManager == nil || t.sessionType == "" {
+		return
+	}
+
+	session := SessionRecord{
+		ID:          t.sessionID,
+		TimerName:   t.name,
+		Type:        t.sessionType,
+		Annotation:  t.annotation,
+		Duration:    t.duration,
+		StartTime:   t.startTime,
+		EndTime:     t.interruptedAt,
+		Completed:   t.planReached,
+		Interrupted: true,
+		Pauses:      closePauses(t.pauses, t.interruptedAt),
+		Notes:       t.notes,
+		Overtime:    t.overtimeLocked(),
+	}
+	t.addSessionLocked(session)
+}
+
+// heartbeatLocked tells a later process that the owner of the timer state is
+// still alive, refreshing the lock at most once per heartbeatInterval. Owners
+// poll the timer while they run, so polling keeps the heartbeat fresh. The
+// caller must hold t.mu.
+func (t *Timer) heartbeatLocked() {
+	now := t.clock.Now()
+	if t.stateManager == nil || !t.ownsState || now.Sub(t.heartbeatAt) < heartbeatInterval {
+		return
+	}
+	t.heartbeatAt = now
+	if err := t.stateManager.RefreshLock(now); err != nil {
+		logger.Warn("Failed to refresh timer lock", map[string]interface{}{"error": err.Error()})
+	}
+}
+
+// Close gives up ownership of the persisted timer state.
+func (t *Timer) Close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.stateManager == nil || !t.ownsState {
+		return
+	}
+	if err := t.stateManager.ReleaseLock(); err != nil {
+		logger.Warn("Failed to release timer lock", map[string]interface{}{"error": err.Error()})
+	}
+	t.ownsState = false
+}
@@ -0,0 +1,248 @@
+package timer
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+// deadPID is a process ID far above any real PID limit.
+const deadPID = 1 << 30
+
+// newRecoveryFixture persists state and, if given, the lock of its previous
+// owner, and returns managers for them.
+func newRecoveryFixture(t *testing.T, state State, lock *Lock) (*StateManager, *HistoryManager) {
+	t.Helper()
+	sm, hm := newTestManagers(t)
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatalf("failed to marshal state: %v", err)
+	}
+	if err := os.WriteFile(sm.stateFile, data, 0600); err != nil {
+		t.Fatalf("failed to write state: %v", err)
+	}
+	if lock != nil {
+		if err := sm.writeLock(*lock); err != nil {
+			t.Fatalf("failed to write lock: %v", err)
+		}
+	}
+	return sm, hm
+}
+
+func TestRecoveryRecordsInterruptedSession(t *testing.T) {
+	start := testStart
+	sm, hm := newRecoveryFixture(t,
+		State{Status: StatusRunning, SessionID: "s1", SessionType: SessionTypeWork, Duration: 25 * time.Minute, StartTime: start},
+		&Lock{PID: deadPID, Heartbeat: start.Add(10 * time.Minute)})
+
+	clock := NewFakeClock(start.Add(3 * time.Hour))
+	timer := NewTimerWithManagers(sm, hm, clock)
+	defer timer.Close()
+
+	if status := timer.GetStatus(); status != StatusInterrupted {
+		t.Fatalf("expected interrupted status, got %v", status)
+	}
+	if elapsed := timer.GetElapsed(); elapsed != 10*time.Minute {
+		t.Errorf("expected elapsed up to the last heartbeat, got %v", elapsed)
+	}
+	if err := timer.Start(time.Minute); err == nil {
+		t.Error("expected starting a new session to fail before recovery")
+	}
+
+	if err := timer.Recover(RecoveryRecord); err != nil {
+		t.Fatalf("failed to recover session: %v", err)
+	}
+	if status := timer.GetStatus(); status != StatusIdle {
+		t.Errorf("expected idle status, got %v", status)
+	}
+
+	last, err := hm.GetLastSession()
+	if err != nil {
+		t.Fatalf("failed to read history: %v", err)
+	}
+	if last.ID != "s1" || last.Completed || !last.Interrupted {
+		t.Errorf("expected interrupted, not completed record, got %+v", last)
+	}
+	if !last.EndTime.Equal(start.Add(10 * time.Minute)) {
+		t.Errorf("expected session to end at the last heartbeat, got %v", last.EndTime)
+	}
+}
+
+func TestRecoveryResumesInterruptedSession(t *testing.T) {
+	start := testStart
+	sm, hm := newRecoveryFixture(t,
+		State{Status: StatusRunning, SessionType: SessionTypeWork, Duration: 25 * time.Minute, StartTime: start},
+		&Lock{PID: deadPID, Heartbeat: start.Add(10 * time.Minute)})
+
+	clock := NewFakeClock(start.Add(3 * time.Hour))
+	timer := NewTimerWithManagers(sm, hm, clock)
+	defer timer.Close()
+
+	if err := timer.Recover(RecoveryResume); err != nil {
+		t.Fatalf("failed to recover session: %v", err)
+	}
+	pauses := timer.Snapshot().Pauses
+	if len(pauses) != 1 || pauses[0].Reason != "interrupted" || pauses[0].Duration() != 170*time.Minute {
+		t.Errorf("expected the downtime to be recorded as a pause, got %+v", pauses)
+	}
+	clock.Advance(14 * time.Minute)
+	if status := timer.GetStatus(); status != StatusRunning {
+		t.Fatalf("expected running status, got %v", status)
+	}
+	clock.Advance(time.Minute)
+	if status := timer.GetStatus(); status != StatusCompleted {
+		t.Errorf("expected completed status, got %v", status)
+	}
+}
+
+func TestRecoveryDiscardsInterruptedSession(t *testing.T) {
+	start := testStart
+	sm, hm := newRecoveryFixture(t,
+		State{Status: StatusPaused, SessionType: SessionTypeWork, Duration: 25 * time.Minute, StartTime: start, Elapsed: 5 * time.Minute},
+		&Lock{PID: deadPID, Heartbeat: start.Add(time.Hour)})
+
+	timer := NewTimerWithManagers(sm, hm, NewFakeClock(start.Add(2*time.Hour)))
+	defer timer.Close()
+
+	if elapsed := timer.GetElapsed(); elapsed != 5*time.Minute {
+		t.Errorf("expected paused elapsed time to be kept, got %v", elapsed)
+	}
+	if err := timer.Recover(RecoveryDiscard); err != nil {
+		t.Fatalf("failed to recover session: %v", err)
+	}
+	sessions, err := hm.GetRecentSessions(10)
+	if err != nil {
+		t.Fatalf("failed to read history: %v", err)
+	}
+	if len(sessions) != 0 {
+		t.Errorf("expected no history entries, got %d", len(sessions))
+	}
+	if err := timer.Recover(RecoveryDiscard); err == nil {
+		t.Error("expected error with no interrupted session")
+	}
+}
+
+func TestRecoveryInterruptsSessionWithoutOwner(t *testing.T) {
+	start := testStart
+	sm, hm := newRecoveryFixture(t,
+		State{Status: StatusRunning, SessionType: SessionTypeWork, Duration: 25 * time.Minute, StartTime: start},
+		nil)
+
+	timer := NewTimerWithManagers(sm, hm, NewFakeClock(start.Add(10*time.Minute)))
+	if status := timer.GetStatus(); status != StatusInterrupted {
+		t.Errorf("expected session left without an owner to be interrupted, got %v", status)
+	}
+	if elapsed := timer.GetElapsed(); elapsed != 0 {
+		t.Errorf("expected no time counted past the last save, got %v", elapsed)
+	}
+
+	timer.Close()
+	if lock, err := sm.readLock(); err != nil || lock != nil {
+		t.Errorf("expected lock to be released, got %+v (%v)", lock, err)
+	}
+}
+
+func TestRecoveryLeavesLiveOwnerAlone(t *testing.T) {
+	start := testStart
+	owner := startLockOwner(t)
+	sm, hm := newRecoveryFixture(t,
+		State{Status: StatusRunning, SessionType: SessionTypeWork, Duration: 25 * time.Minute, StartTime: start},
+		&Lock{PID: owner, Heartbeat: start.Add(9 * time.Minute)})
+
+	timer := NewTimerWithManagers(sm, hm, NewFakeClock(start.Add(10*time.Minute)))
+	timer.Close()
+	if status := timer.GetStatus(); status != StatusRunning {
+		t.Errorf("expected session owned by a live process to keep running, got %v", status)
+	}
+	if lock, err := sm.readLock(); err != nil || lock == nil || lock.PID != owner {
+		t.Errorf("expected lock of the live owner to be kept, got %+v (%v)", lock, err)
+	}
+}
+
+func TestRecoveryTakesOverStaleOwner(t *testing.T) {
+	start := testStart
+	tests := []struct {
+		name      string
+		pid       func(t *testing.T) int
+		heartbeat time.Time
+	}{
+		// After a reboot the old PID may belong to another process
+		{"unrelated process, old heartbeat", func(*testing.T) int { return os.Getppid() }, start.Add(10 * time.Minute)},
+		{"pomodux process, old heartbeat", startLockOwner, start.Add(10 * time.Minute)},
+		{"unrelated process, fresh heartbeat", func(t *testing.T) int {
+			if _, err := os.Readlink("/proc/self/exe"); err != nil {
+				t.Skip("cannot tell which program a process runs here")
+			}
+			return os.Getppid()
+		}, start.Add(3 * time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sm, hm := newRecoveryFixture(t,
+				State{Status: StatusRunning, SessionType: SessionTypeWork, Duration: 25 * time.Minute, StartTime: start},
+				&Lock{PID: tt.pid(t), Heartbeat: tt.heartbeat})
+
+			timer := NewTimerWithManagers(sm, hm, NewFakeClock(start.Add(3*time.Hour)))
+			defer timer.Close()
+			if status := timer.GetStatus(); status != StatusInterrupted {
+				t.Errorf("expected session of a stale owner to be interrupted, got %v", status)
+			}
+			if lock, err := sm.readLock(); err != nil || lock == nil || lock.PID != os.Getpid() {
+				t.Errorf("expected the lock to be taken over, got %+v (%v)", lock, err)
+			}
+		})
+	}
+}
+
+func TestTimerRefreshesHeartbeatWhilePolled(t *testing.T) {
+	timer, clock := newTestTimer(t)
+	if err := timer.Start(25 * time.Minute); err != nil {
+		t.Fatalf("failed to start timer: %v", err)
+	}
+
+	clock.Advance(heartbeatInterval)
+	timer.GetStatus()
+	lock, err := timer.stateManager.readLock()
+	if err != nil || lock == nil || !lock.Heartbeat.Equal(clock.Now()) {
+		t.Errorf("expected the heartbeat to be refreshed, got %+v (%v)", lock, err)
+	}
+}
+
+// TestLockOwnerProcess is not a real test. It stands in for another pomodux
+// process holding the lock when run by startLockOwner.
+func TestLockOwnerProcess(t *testing.T) {
+	if os.Getenv("POMODUX_LOCK_OWNER") != "1" {
+		t.Skip("only run by startLockOwner")
+	}
+	time.Sleep(time.Minute)
+}
+
+// startLockOwner starts another process running this test binary, which lives
+// until the test ends, and returns its PID.
+func startLockOwner(t *testing.T) int {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], "-test.run=^TestLockOwnerProcess$")
+	cmd.Env = append(os.Environ(), "POMODUX_LOCK_OWNER=1")
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("failed to start lock owner: %v", err)
+	}
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	})
+	return cmd.Process.Pid
+}
+
+func TestParseRecoveryAction(t *testing.T) {
+	for _, name := range []string{"record", "resume", "discard"} {
+		if _, err := ParseRecoveryAction(name); err != nil {
+			t.Errorf("expected %q to parse: %v", name, err)
+		}
+	}
+	if _, err := ParseRecoveryAction("keep"); err == nil {
+		t.Error("expected error for unknown action")
+	}
+}
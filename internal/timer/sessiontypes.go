@@ -0,0 +1,53 @@
+package timer
+
+import "time"
+
+// SessionTypeDef describes a user-defined session type, such as a code
+// review or a stand-up.
+type SessionTypeDef struct {
+	Name SessionType
+	// Duration is how long sessions of the type last unless given a duration
+	Duration time.Duration
+	// Work makes sessions of the type count as work in goals, streaks and
+	// statistics
+	Work bool
+	// Message is the text plugins are given to notify with when a session
+	// of the type completes
+	Message string
+}
+
+// SessionTypes holds the user-defined session types by name.
+type SessionTypes map[SessionType]SessionTypeDef
+
+// NewSessionTypes collects user-defined session types by name.
+func NewSessionTypes(defs []SessionTypeDef) SessionTypes {
+	types := make(SessionTypes, len(defs))
+	for _, def := range defs {
+		types[def.Name] = def
+	}
+	return types
+}
+
+// CountsAsWork reports whether sessions of type s count as work: work
+// sessions do, and so do user-defined types marked as work.
+func (types SessionTypes) CountsAsWork(s SessionType) bool {
+	return s == SessionTypeWork || types[s].Work
+}
+
+// IsPomodoro reports whether a session counts as a pomodoro: a session of a
+// type that counts as work, but not an open-ended stopwatch session.
+func (types SessionTypes) IsPomodoro(session SessionRecord) bool {
+	return types.CountsAsWork(session.Type) && !session.OpenEnded()
+}
+
+// IsBuiltin reports whether s is one of the Pomodoro cycle's session types.
+func (s SessionType) IsBuiltin() bool {
+	return s == SessionTypeWork || s == SessionTypeBreak || s == SessionTypeLongBreak
+}
+
+// SetSessionTypes sets the user-defined session types the timer runs.
+func (t *Timer) SetSessionTypes(types SessionTypes) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sessionTypes = types
+}
@@ -0,0 +1,59 @@
+package timer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCustomSessionTypes(t *testing.T) {
+	types := NewSessionTypes([]SessionTypeDef{
+		{Name: "deep-work", Duration: 90 * time.Minute, Work: true},
+		{Name: "standup", Duration: 10 * time.Minute, Message: "Stand-up over"},
+	})
+
+	if !types.CountsAsWork(SessionTypeWork) || !types.CountsAsWork("deep-work") || types.CountsAsWork("standup") || types.CountsAsWork("unknown") {
+		t.Errorf("expected only work types to count as work")
+	}
+	if SessionTypes(nil).CountsAsWork("deep-work") {
+		t.Errorf("expected types that are not defined not to count as work")
+	}
+	if !types.IsPomodoro(SessionRecord{Type: "deep-work", Duration: time.Hour}) || types.IsPomodoro(SessionRecord{Type: "deep-work"}) {
+		t.Errorf("expected work sessions to be pomodoros unless tracked with a stopwatch")
+	}
+
+	// Work types count towards goals
+	now := time.Date(2025, 1, 8, 15, 0, 0, 0, time.UTC)
+	sessions := []SessionRecord{
+		{Type: "deep-work", Duration: 90 * time.Minute, StartTime: now.Add(-2 * time.Hour), EndTime: now.Add(-30 * time.Minute), Completed: true},
+		{Type: "standup", Duration: 10 * time.Minute, StartTime: now.Add(-3 * time.Hour), EndTime: now.Add(-170 * time.Minute), Completed: true},
+	}
+	progress := GoalsProgress(Goals{DailyPomodoros: 2}, types, sessions, now)
+	if progress[0].Done != 1 {
+		t.Errorf("expected only the deep work session to count, got %d", progress[0].Done)
+	}
+}
+
+func TestCustomSessionTypeLeavesCycle(t *testing.T) {
+	clock := NewFakeClock(testStart)
+	timer := NewTimerWithClock(clock)
+	timer.SetSessionTypes(NewSessionTypes([]SessionTypeDef{{Name: "review", Duration: 15 * time.Minute, Work: true}}))
+
+	if err := timer.StartWithType(25*time.Minute, SessionTypeWork); err != nil {
+		t.Fatalf("failed to start work session: %v", err)
+	}
+	clock.Advance(25 * time.Minute)
+	timer.GetStatus()
+	if err := timer.StartWithType(15*time.Minute, "review"); err != nil {
+		t.Fatalf("failed to start review session: %v", err)
+	}
+	clock.Advance(15 * time.Minute)
+	timer.GetStatus()
+
+	cycle := timer.GetCycle()
+	if cycle.Pomodoros != 1 || cycle.LastSessionType != SessionTypeWork {
+		t.Errorf("expected the review to leave the cycle alone, got %+v", cycle)
+	}
+	if next := cycle.Next(4); next != SessionTypeBreak {
+		t.Errorf("expected a break next, got %s", next)
+	}
+}
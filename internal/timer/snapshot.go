@@ -0,0 +1,77 @@
+package timer
+
+import "time"
+
+// Snapshot is a point-in-time view of a timer. It is what the daemon sends to
+// clients, so it only contains plain, JSON-serializable values.
+type Snapshot struct {
+	// TimerName is the name of a named timer; empty for the default timer
+	TimerName   string      `json:"timer_name,omitempty"`
+	Status      TimerStatus `json:"status"`
+	SessionID   string      `json:"session_id,omitempty"`
+	SessionType SessionType `json:"session_type"`
+	Annotation
+	StartTime time.Time     `json:"start_time"`
+	Duration  time.Duration `json:"duration"`
+	Elapsed   time.Duration `json:"elapsed"`
+	Remaining time.Duration `json:"remaining"`
+	Progress  float64       `json:"progress"`
+	// EndTime is when a running session will reach its planned duration
+	EndTime time.Time       `json:"end_time,omitempty"`
+	Pauses  []PauseInterval `json:"pauses,omitempty"`
+	Notes   []Note          `json:"notes,omitempty"`
+	// Stopwatch is set for open-ended sessions, which have no duration
+	Stopwatch bool `json:"stopwatch,omitempty"`
+	// OvertimeMode keeps the session counting past its planned duration
+	OvertimeMode bool `json:"overtime_mode,omitempty"`
+	// Overtime is how long the session has run past its planned duration
+	Overtime time.Duration `json:"overtime,omitempty"`
+	// Pomodoros is the number of work sessions completed since the last long break
+	Pomodoros         int         `json:"pomodoros"`
+	LongBreakInterval int         `json:"long_break_interval"`
+	NextSessionType   SessionType `json:"next_session_type"`
+}
+
+// Snapshot returns the current state of the timer, completing the session
+// first if its duration has elapsed.
+func (t *Timer) Snapshot() Snapshot {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.heartbeatLocked()
+	t.checkCompletionLocked()
+
+	elapsed := t.elapsedLocked()
+	remaining := t.duration - elapsed
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	var endTime time.Time
+	if t.status == StatusRunning && !t.stopwatchLocked() && !t.planReached {
+		endTime = t.clock.Now().Add(remaining)
+	}
+
+	return Snapshot{
+		TimerName:   t.name,
+		Status:      t.status,
+		SessionID:   t.sessionID,
+		SessionType: t.sessionType,
+		Annotation:  t.annotation,
+		StartTime:   t.startTime,
+		Duration:    t.duration,
+		Elapsed:     elapsed,
+		Remaining:   remaining,
+		Progress:    t.progressLocked(),
+		EndTime:     endTime,
+		Pauses:      append([]PauseInterval(nil), t.pauses...),
+		Notes:       append([]Note(nil), t.notes...),
+
+		Stopwatch:    t.stopwatchLocked() && t.status != StatusIdle,
+		OvertimeMode: t.overtime,
+		Overtime:     t.overtimeLocked(),
+
+		Pomodoros:         t.cycle.Pomodoros,
+		LongBreakInterval: t.cycleConfig.LongBreakInterval,
+		NextSessionType:   t.upcomingSessionTypeLocked(),
+	}
+}
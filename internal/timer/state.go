@@ -5,23 +5,67 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 	"time"
+	"unicode"
 )
 
 // State represents the persistent timer state
 type State struct {
-	Status      TimerStatus   `json:"status"`
-	SessionType SessionType   `json:"session_type"`
-	Duration    time.Duration `json:"duration"`
-	StartTime   time.Time     `json:"start_time"`
-	Elapsed     time.Duration `json:"elapsed"`
+	Status      TimerStatus `json:"status"`
+	SessionID   string      `json:"session_id,omitempty"`
+	SessionType SessionType `json:"session_type"`
+	Annotation
+	Duration  time.Duration `json:"duration"`
+	StartTime time.Time     `json:"start_time"`
+	Elapsed   time.Duration `json:"elapsed"`
+	Cycle     Cycle         `json:"cycle"`
+	// InterruptedAt is when the owner of an interrupted session was last alive
+	InterruptedAt time.Time `json:"interrupted_at,omitempty"`
+	// ResumedAt is when the session last started or resumed running
+	ResumedAt time.Time       `json:"resumed_at"`
+	Pauses    []PauseInterval `json:"pauses,omitempty"`
+	Notes     []Note          `json:"notes,omitempty"`
+	// OvertimeMode keeps the session counting past its planned duration
+	OvertimeMode bool `json:"overtime_mode,omitempty"`
+	// PlanReached is set once an overtime session has passed its planned duration
+	PlanReached bool `json:"plan_reached,omitempty"`
 }
 
-// StateManager handles persistent timer state
+// DefaultTimerName is the key the unnamed timer is stored under in the state file.
+const DefaultTimerName = "default"
+
+// ValidateTimerName checks that name can name a timer: letters, digits,
+// '-', '_' and '.', at most 32 of them.
+func ValidateTimerName(name string) error {
+	if name == "" || len(name) > 32 {
+		return fmt.Errorf("timer name must be 1 to 32 characters")
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' && r != '.' {
+			return fmt.Errorf("invalid timer name %q: use letters, digits, '-', '_' or '.'", name)
+		}
+	}
+	return nil
+}
+
+// stateFileContents is the layout of the state file: the state of every
+// timer, keyed by name.
+type stateFileContents struct {
+	Timers map[string]State `json:"timers"`
+}
+
+// StateManager handles persistent timer state. Every timer is kept in the
+// same state file; a StateManager reads and writes the entry of one of them.
 type StateManager struct {
 	stateFile string
-	mu        sync.Mutex
+	// name is the timer whose entry is managed; empty for the default timer
+	name string
+	// previous is the lock left behind by the previous owner of the state,
+	// found when this process first acquired it
+	previous *Lock
+	mu       sync.Mutex
 }
 
 // NewStateManager creates a new state manager
@@ -35,17 +79,46 @@ func NewStateManager() (*StateManager, error) {
 	return &StateManager{stateFile: stateFile}, nil
 }
 
-// SaveState saves the current timer state to file
+// ForName returns a StateManager for the named timer's entry in the same state file.
+func (sm *StateManager) ForName(name string) *StateManager {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	if name == DefaultTimerName {
+		name = ""
+	}
+	return &StateManager{stateFile: sm.stateFile, name: name, previous: sm.previous}
+}
+
+// key returns the timer's key in the state file.
+func (sm *StateManager) key() string {
+	if sm.name == "" {
+		return DefaultTimerName
+	}
+	return sm.name
+}
+
+// SaveState saves the current timer state to file. A named timer's entry is
+// removed once it has no session, so the file only lists timers in use.
 func (sm *StateManager) SaveState(timer *Timer) error {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
 	state := State{
 		Status:      timer.status,
+		SessionID:   timer.sessionID,
 		SessionType: timer.sessionType,
+		Annotation:  timer.annotation,
 		Duration:    timer.duration,
 		StartTime:   timer.startTime,
 		Elapsed:     timer.elapsed,
+		Cycle:       timer.cycle,
+
+		InterruptedAt: timer.interruptedAt,
+		ResumedAt:     timer.resumedAt,
+		Pauses:        timer.pauses,
+		Notes:         timer.notes,
+		OvertimeMode:  timer.overtime,
+		PlanReached:   timer.planReached,
 	}
 
 	// Ensure state directory exists
@@ -54,12 +127,20 @@ func (sm *StateManager) SaveState(timer *Timer) error {
 		return fmt.Errorf("failed to create state directory: %w", err)
 	}
 
-	data, err := json.Marshal(state)
+	// Lock across processes so concurrent pomodux commands cannot interleave writes
+	err := withFileLock(sm.stateFile, func() error {
+		contents, err := sm.readFile()
+		if err != nil {
+			return err
+		}
+		if sm.name != "" && (state.Status == StatusIdle || state.Status == StatusCompleted) {
+			delete(contents.Timers, sm.key())
+		} else {
+			contents.Timers[sm.key()] = state
+		}
+		return sm.writeFile(contents)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to marshal state: %w", err)
-	}
-
-	if err := os.WriteFile(sm.stateFile, data, 0600); err != nil {
 		return fmt.Errorf("failed to write state file: %w", err)
 	}
 
@@ -68,30 +149,96 @@ func (sm *StateManager) SaveState(timer *Timer) error {
 
 // LoadState loads timer state from file
 func (sm *StateManager) LoadState() (*State, error) {
-	if _, err := os.Stat(sm.stateFile); os.IsNotExist(err) {
+	contents, err := sm.readFile()
+	if err != nil {
+		return nil, err
+	}
+
+	state, ok := contents.Timers[sm.key()]
+	if !ok {
 		return &State{Status: StatusIdle}, nil
 	}
+	return &state, nil
+}
+
+// Names returns the names of the named timers in the state file, sorted.
+// The default timer is not included.
+func (sm *StateManager) Names() ([]string, error) {
+	contents, err := sm.readFile()
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for name := range contents.Timers {
+		if name != DefaultTimerName {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ClearState removes the timer's state, and the state file once no timer is left in it
+func (sm *StateManager) ClearState() error {
+	return withFileLock(sm.stateFile, func() error {
+		contents, err := sm.readFile()
+		if err != nil {
+			return err
+		}
+		delete(contents.Timers, sm.key())
+		if len(contents.Timers) == 0 {
+			if err := os.Remove(sm.stateFile); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			return nil
+		}
+		return sm.writeFile(contents)
+	})
+}
+
+// readFile reads every timer's state. A state file written before timers
+// were named holds only the state of the default timer.
+func (sm *StateManager) readFile() (*stateFileContents, error) {
+	contents := &stateFileContents{Timers: map[string]State{}}
 
 	data, err := os.ReadFile(sm.stateFile)
+	if os.IsNotExist(err) {
+		return contents, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to read state file: %w", err)
 	}
 
-	var state State
-	if err := json.Unmarshal(data, &state); err != nil {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal state: %w", err)
 	}
+	if _, ok := fields["timers"]; !ok {
+		var state State
+		if err := json.Unmarshal(data, &state); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal state: %w", err)
+		}
+		contents.Timers[DefaultTimerName] = state
+		return contents, nil
+	}
 
-	return &state, nil
+	if err := json.Unmarshal(data, contents); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal state: %w", err)
+	}
+	if contents.Timers == nil {
+		contents.Timers = map[string]State{}
+	}
+	return contents, nil
 }
 
-// ClearState removes the state file
-func (sm *StateManager) ClearState() error {
-	if _, err := os.Stat(sm.stateFile); os.IsNotExist(err) {
-		return nil // File doesn't exist, nothing to clear
+// writeFile writes every timer's state. The caller must hold the file lock.
+func (sm *StateManager) writeFile(contents *stateFileContents) error {
+	data, err := json.Marshal(contents)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
 	}
-
-	return os.Remove(sm.stateFile)
+	return writeFileAtomic(sm.stateFile, data, 0600)
 }
 
 // getStateDir returns the XDG-compliant state directory
@@ -0,0 +1,89 @@
+package timer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNamedTimersShareStateFile(t *testing.T) {
+	sm, hm := newTestManagers(t)
+	clock := NewFakeClock(testStart)
+
+	pomodoro := NewTimerWithManagers(sm, hm, clock)
+	defer pomodoro.Close()
+	deploy := NewTimerWithManagers(sm.ForName("deploy"), hm, clock)
+	defer deploy.Close()
+
+	if err := pomodoro.Start(25 * time.Minute); err != nil {
+		t.Fatalf("failed to start default timer: %v", err)
+	}
+	if err := deploy.Start(10 * time.Minute); err != nil {
+		t.Fatalf("failed to start named timer: %v", err)
+	}
+
+	state, err := sm.LoadState()
+	if err != nil || state.Duration != 25*time.Minute {
+		t.Fatalf("expected the default timer's state, got %+v (%v)", state, err)
+	}
+	state, err = sm.ForName("deploy").LoadState()
+	if err != nil || state.Duration != 10*time.Minute {
+		t.Fatalf("expected the named timer's state, got %+v (%v)", state, err)
+	}
+	if names, _ := sm.Names(); len(names) != 1 || names[0] != "deploy" {
+		t.Errorf("expected named timers [deploy], got %v", names)
+	}
+
+	clock.Advance(10 * time.Minute)
+	if status := deploy.GetStatus(); status != StatusCompleted {
+		t.Fatalf("expected the named timer to complete, got %v", status)
+	}
+	if status := pomodoro.GetStatus(); status != StatusRunning {
+		t.Errorf("expected the default timer to keep running, got %v", status)
+	}
+	if names, _ := sm.Names(); len(names) != 0 {
+		t.Errorf("expected the finished named timer to be removed, got %v", names)
+	}
+
+	last, err := hm.GetLastSession()
+	if err != nil || last.TimerName != "deploy" {
+		t.Errorf("expected the named session in history, got %+v (%v)", last, err)
+	}
+}
+
+func TestNamedTimerFoundInterrupted(t *testing.T) {
+	start := testStart
+	sm, hm := newRecoveryFixture(t, State{Status: StatusIdle}, &Lock{PID: deadPID, Heartbeat: start.Add(5 * time.Minute)})
+	clock := NewFakeClock(start)
+
+	// A named session left running by the dead owner
+	previous := sm.ForName("deploy")
+	if err := previous.SaveState(&Timer{status: StatusRunning, sessionType: SessionTypeWork, duration: 10 * time.Minute, startTime: start, resumedAt: start}); err != nil {
+		t.Fatalf("failed to save named state: %v", err)
+	}
+
+	clock.Advance(time.Hour)
+	pomodoro := NewTimerWithManagers(sm, hm, clock)
+	defer pomodoro.Close()
+	deploy := NewTimerWithManagers(sm.ForName("deploy"), hm, clock)
+	defer deploy.Close()
+
+	if status := deploy.GetStatus(); status != StatusInterrupted {
+		t.Fatalf("expected the named session to be interrupted, got %v", status)
+	}
+	if elapsed := deploy.GetElapsed(); elapsed != 5*time.Minute {
+		t.Errorf("expected elapsed up to the last heartbeat, got %v", elapsed)
+	}
+}
+
+func TestValidateTimerName(t *testing.T) {
+	for _, name := range []string{"deploy", "meeting-1", "v2.0_check"} {
+		if err := ValidateTimerName(name); err != nil {
+			t.Errorf("expected %q to be valid, got %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "two words", "a/b", "waytoolongforatimernamebecauseitgoesonandon"} {
+		if err := ValidateTimerName(name); err == nil {
+			t.Errorf("expected %q to be invalid", name)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package timer
+
+// StartStopwatch starts an open-ended work session that counts up with no
+// target until it is stopped. It can be paused and resumed like any other
+// session, and is recorded in history with the time it actually ran.
+func (t *Timer) StartStopwatch(annotation Annotation) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.beginLocked(0, SessionTypeWork, annotation, false)
+}
+
+// stopwatchLocked reports whether the current session is an open-ended
+// stopwatch session. The caller must hold t.mu.
+func (t *Timer) stopwatchLocked() bool {
+	return t.duration == 0
+}
+
+// OpenEnded reports whether the session was an open-ended stopwatch session.
+func (s SessionRecord) OpenEnded() bool {
+	return s.Duration == 0
+}
@@ -0,0 +1,78 @@
+package timer
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTimerStopwatchCountsUpUntilStopped(t *testing.T) {
+	timer, clock := newTestTimer(t)
+	history := timer.historyManager
+
+	if err := timer.StartStopwatch(NewAnnotation("Production incident", nil)); err != nil {
+		t.Fatalf("failed to start stopwatch: %v", err)
+	}
+	clock.Advance(3 * time.Hour)
+
+	snapshot := timer.Snapshot()
+	if snapshot.Status != StatusRunning || !snapshot.Stopwatch {
+		t.Fatalf("expected a running stopwatch, got %+v", snapshot)
+	}
+	if snapshot.Elapsed != 3*time.Hour {
+		t.Errorf("expected 3h elapsed, got %v", snapshot.Elapsed)
+	}
+
+	if err := timer.Pause(); err != nil {
+		t.Fatalf("failed to pause stopwatch: %v", err)
+	}
+	clock.Advance(time.Hour)
+	if err := timer.Resume(); err != nil {
+		t.Fatalf("failed to resume stopwatch: %v", err)
+	}
+	clock.Advance(30 * time.Minute)
+
+	if err := timer.Skip(); err == nil {
+		t.Errorf("expected skipping a stopwatch session to fail")
+	}
+	if err := timer.Stop(); err != nil {
+		t.Fatalf("failed to stop stopwatch: %v", err)
+	}
+
+	last, err := history.GetLastSession()
+	if err != nil {
+		t.Fatalf("failed to read history: %v", err)
+	}
+	if !last.OpenEnded() || !last.Completed || last.Task != "Production incident" {
+		t.Errorf("expected a completed open-ended session, got %+v", last)
+	}
+	if active := last.EndTime.Sub(last.StartTime) - last.PausedTime(); active != 3*time.Hour+30*time.Minute {
+		t.Errorf("expected 3h30m of tracked time, got %v", active)
+	}
+	if cycle := timer.GetCycle(); cycle.Pomodoros != 0 {
+		t.Errorf("expected stopwatch sessions not to count as pomodoros, got %d", cycle.Pomodoros)
+	}
+}
+
+func TestTimerStopwatchSurvivesRestart(t *testing.T) {
+	dir := t.TempDir()
+	clock := NewFakeClock(testStart)
+	stateManager := &StateManager{stateFile: filepath.Join(dir, "timer_state.json")}
+
+	timer := NewTimerWithManagers(stateManager, nil, clock)
+	if err := timer.StartStopwatch(Annotation{}); err != nil {
+		t.Fatalf("failed to start stopwatch: %v", err)
+	}
+	clock.Advance(10 * time.Minute)
+	if err := timer.Pause(); err != nil {
+		t.Fatalf("failed to pause stopwatch: %v", err)
+	}
+	timer.Close()
+
+	// Without an owner the stopwatch is held for recovery
+	restored := NewTimerWithManagers(stateManager, nil, clock)
+	snapshot := restored.Snapshot()
+	if snapshot.Status != StatusInterrupted || !snapshot.Stopwatch || snapshot.Elapsed != 10*time.Minute {
+		t.Errorf("expected the paused stopwatch to be restored, got %+v", snapshot)
+	}
+}
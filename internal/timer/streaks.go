@@ -0,0 +1,157 @@
+package timer
+
+import (
+	"sort"
+	"time"
+
+	"github.com/rsmacapinlac/pomodux/internal/logger"
+	"github.com/rsmacapinlac/pomodux/internal/plugin"
+)
+
+// StreakConfig sets what a day needs to count towards a streak.
+type StreakConfig struct {
+	// MinPomodoros is the number of completed work sessions a day needs;
+	// streaks are not tracked when zero
+	MinPomodoros int
+	// DayStartHour is the hour days begin at, so sessions started before it
+	// count for the previous day
+	DayStartHour int
+}
+
+// Enabled reports whether streaks are tracked.
+func (c StreakConfig) Enabled() bool {
+	return c.MinPomodoros > 0
+}
+
+// Day returns the calendar day t counts for, as midnight UTC of that date.
+func (c StreakConfig) Day(t time.Time) time.Time {
+	t = t.Add(-time.Duration(c.DayStartHour) * time.Hour)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Streak describes runs of consecutive days that each met the minimum
+// number of pomodoros.
+type Streak struct {
+	// Current is the run ending today, or yesterday while today can still
+	// extend it; zero once the run is broken
+	Current int
+	// Longest is the longest run ever
+	Longest int
+	// Today is the number of pomodoros counted for today so far
+	Today int
+	// Last is the run ending on LastDay, kept after it is broken
+	Last int
+	// LastDay is the most recent day that met the minimum; zero if none has
+	LastDay time.Time
+}
+
+// Broken reports whether the most recent run has ended.
+func (s Streak) Broken() bool {
+	return s.Last > 0 && s.Current == 0
+}
+
+// Streaks measures streaks as of now. Only completed sessions on the default
+// timer that count as work, given the user-defined types, count; stopwatch
+// sessions do not.
+func Streaks(config StreakConfig, types SessionTypes, sessions []SessionRecord, now time.Time) Streak {
+	counts := make(map[time.Time]int)
+	for _, session := range sessions {
+		if !types.IsPomodoro(session) || session.TimerName != "" || !session.Completed || session.StartTime.After(now) {
+			continue
+		}
+		counts[config.Day(session.StartTime)]++
+	}
+
+	today := config.Day(now)
+	streak := Streak{Today: counts[today]}
+	if !config.Enabled() {
+		return streak
+	}
+
+	var days []time.Time
+	for day, count := range counts {
+		if count >= config.MinPomodoros {
+			days = append(days, day)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+
+	run := 0
+	for i, day := range days {
+		if i > 0 && days[i-1].AddDate(0, 0, 1).Equal(day) {
+			run++
+		} else {
+			run = 1
+		}
+		if run > streak.Longest {
+			streak.Longest = run
+		}
+	}
+
+	if len(days) > 0 {
+		streak.Last = run
+		streak.LastDay = days[len(days)-1]
+		if !streak.LastDay.Before(today.AddDate(0, 0, -1)) {
+			streak.Current = run
+		}
+	}
+	return streak
+}
+
+// SetStreaks sets what counts towards the streaks the timer tells plugins
+// about.
+func (t *Timer) SetStreaks(config StreakConfig) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.streaks = config
+}
+
+// emitStreakEventsLocked tells plugins when the sessions recorded before
+// show a streak has been broken, and when recording session extends the
+// current streak. The caller must hold t.mu.
+func (t *Timer) emitStreakEventsLocked(before, after []SessionRecord) {
+	now := t.clock.Now()
+	was := Streaks(t.streaks, t.sessionTypes, before, now)
+	is := Streaks(t.streaks, t.sessionTypes, after, now)
+
+	if t.streakBrokenUnnoticed(was, before) {
+		t.pluginManager.EmitEvent(plugin.Event{
+			Type:      plugin.EventStreakBroken,
+			Timestamp: now,
+			Data: map[string]interface{}{
+				"length":   was.Last,
+				"last_day": was.LastDay.Format("2006-01-02"),
+				"longest":  was.Longest,
+			},
+		})
+		logger.Info("Streak broken", map[string]interface{}{"length": was.Last})
+	}
+
+	if is.Current > was.Current {
+		t.pluginManager.EmitEvent(plugin.Event{
+			Type:      plugin.EventStreakExtended,
+			Timestamp: now,
+			Data: map[string]interface{}{
+				"current": is.Current,
+				"longest": is.Longest,
+				"day":     is.LastDay.Format("2006-01-02"),
+			},
+		})
+		logger.Info("Streak extended", map[string]interface{}{"current": is.Current})
+	}
+}
+
+// streakBrokenUnnoticed reports whether streak is broken and no session on
+// the default timer was recorded since, so the break is told only once.
+func (t *Timer) streakBrokenUnnoticed(streak Streak, before []SessionRecord) bool {
+	if !streak.Broken() {
+		return false
+	}
+	over := streak.LastDay.AddDate(0, 0, 2)
+	for _, session := range before {
+		if session.TimerName == "" && !t.streaks.Day(session.StartTime).Before(over) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,101 @@
+package timer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rsmacapinlac/pomodux/internal/plugin"
+)
+
+func TestStreaks(t *testing.T) {
+	now := time.Date(2025, 1, 10, 15, 0, 0, 0, time.UTC)
+	pomodoro := func(daysAgo int, hour int) SessionRecord {
+		start := time.Date(2025, 1, 10-daysAgo, hour, 0, 0, 0, time.UTC)
+		return SessionRecord{Type: SessionTypeWork, Duration: 25 * time.Minute, StartTime: start, EndTime: start.Add(25 * time.Minute), Completed: true}
+	}
+	sessions := []SessionRecord{
+		// A three day run that ended a week ago
+		pomodoro(9, 10), pomodoro(8, 10), pomodoro(7, 10),
+		// The current run, from three days ago to yesterday
+		pomodoro(3, 10), pomodoro(2, 10), pomodoro(1, 10),
+		// Work that does not count
+		{Type: SessionTypeWork, StartTime: now.Add(-time.Hour), EndTime: now.Add(-50 * time.Minute)},
+		{Type: SessionTypeBreak, StartTime: now.Add(-time.Hour), EndTime: now.Add(-55 * time.Minute), Completed: true},
+		{Type: SessionTypeWork, TimerName: "deploy", Duration: time.Hour, StartTime: now.Add(-time.Hour), EndTime: now, Completed: true},
+		{Type: SessionTypeWork, StartTime: now.Add(-2 * time.Hour), EndTime: now.Add(-118 * time.Minute), Completed: true}, // tracked
+	}
+
+	streak := Streaks(StreakConfig{MinPomodoros: 1}, nil, sessions, now)
+	if streak.Current != 3 || streak.Longest != 3 || streak.Today != 0 {
+		t.Errorf("expected a current streak of 3 kept alive until today ends, got %+v", streak)
+	}
+
+	// Today extends it
+	sessions = append(sessions, pomodoro(0, 9))
+	if streak := Streaks(StreakConfig{MinPomodoros: 1}, nil, sessions, now); streak.Current != 4 || streak.Longest != 4 || streak.Today != 1 {
+		t.Errorf("expected today to extend the streak to 4, got %+v", streak)
+	}
+
+	// Days need enough pomodoros
+	if streak := Streaks(StreakConfig{MinPomodoros: 2}, nil, sessions, now); streak.Current != 0 || streak.Longest != 0 {
+		t.Errorf("expected no streak with two pomodoros a day, got %+v", streak)
+	}
+
+	// A gap breaks the streak
+	later := now.AddDate(0, 0, 2)
+	streak = Streaks(StreakConfig{MinPomodoros: 1}, nil, sessions, later)
+	if !streak.Broken() || streak.Last != 4 || streak.Longest != 4 {
+		t.Errorf("expected the streak to be broken after a missed day, got %+v", streak)
+	}
+}
+
+func TestStreakDayStartHour(t *testing.T) {
+	// Past midnight, but before the day starts at 4am
+	now := time.Date(2025, 1, 10, 1, 30, 0, 0, time.UTC)
+	sessions := []SessionRecord{
+		{Type: SessionTypeWork, Duration: 25 * time.Minute, StartTime: time.Date(2025, 1, 9, 10, 0, 0, 0, time.UTC), Completed: true},
+		{Type: SessionTypeWork, Duration: 25 * time.Minute, StartTime: now.Add(-time.Hour), Completed: true},
+	}
+
+	if streak := Streaks(StreakConfig{MinPomodoros: 2}, nil, sessions, now); streak.Current != 0 {
+		t.Errorf("expected the late session to count for the 10th by default, got %+v", streak)
+	}
+	streak := Streaks(StreakConfig{MinPomodoros: 2, DayStartHour: 4}, nil, sessions, now)
+	if streak.Current != 1 || streak.Today != 2 {
+		t.Errorf("expected the late session to count for the 9th, got %+v", streak)
+	}
+}
+
+func TestTimerEmitsStreakEvents(t *testing.T) {
+	pm := plugin.NewPluginManager(t.TempDir())
+	events := captureEvents(t, pm, map[string][]string{
+		"streak_extended": {"current"},
+		"streak_broken":   {"length"},
+	})
+	timer, clock := newTestTimer(t)
+	timer.SetPluginManager(pm)
+	timer.SetStreaks(StreakConfig{MinPomodoros: 1})
+
+	pomodoro := func() {
+		if err := timer.Start(25 * time.Minute); err != nil {
+			t.Fatalf("failed to start session: %v", err)
+		}
+		clock.Advance(25 * time.Minute)
+		timer.GetStatus()
+	}
+
+	// Two days in a row, with two pomodoros on the second
+	pomodoro()
+	clock.Advance(24 * time.Hour)
+	pomodoro()
+	pomodoro()
+	// Skip a day, then start again twice
+	clock.Advance(48 * time.Hour)
+	pomodoro()
+	pomodoro()
+
+	expected := "streak_extended 1\nstreak_extended 2\nstreak_broken 2\nstreak_extended 1"
+	if events := events(); events != expected {
+		t.Errorf("expected events %q, got %q", expected, events)
+	}
+}
@@ -1,40 +1,64 @@
 package timer
 
 import (
-	"bufio"
-	"context"
 	"fmt"
-	"os"
-	"os/signal"
-	"strings"
 	"sync"
-	"syscall"
 	"time"
 
-	"golang.org/x/term"
-
 	"github.com/rsmacapinlac/pomodux/internal/logger"
 	"github.com/rsmacapinlac/pomodux/internal/plugin"
+	"github.com/rsmacapinlac/pomodux/internal/theme"
 )
 
 // Timer represents a timer instance
 type Timer struct {
-	mu             sync.Mutex
-	status         TimerStatus
-	sessionType    SessionType
-	startTime      time.Time
+	mu     sync.Mutex
+	status TimerStatus
+	// name identifies a named timer; it is empty for the default timer
+	name        string
+	sessionID   string
+	sessionType SessionType
+	annotation  Annotation
+	startTime   time.Time
+	// resumedAt is when the session last started or resumed running
+	resumedAt time.Time
+	pauses    []PauseInterval
+	notes     []Note
+	// overtime keeps the session counting past its planned duration
+	overtime bool
+	// planReached is set once an overtime session has passed its planned duration
+	planReached    bool
 	duration       time.Duration
 	elapsed        time.Duration
+	cycle          Cycle
+	cycleConfig    CycleConfig
+	goals          Goals
+	streaks        StreakConfig
+	sessionTypes   SessionTypes
 	stateManager   *StateManager
-	historyManager *HistoryManager
+	historyManager HistoryStore
 	pluginManager  *plugin.PluginManager
+	clock          Clock
+	// ownsState is set once this timer holds the lock on the persisted state
+	ownsState bool
+	// heartbeatAt is when this timer last refreshed the lock
+	heartbeatAt time.Time
+	// interruptedAt is when the owner of an interrupted session was last alive
+	interruptedAt time.Time
 }
 
 // NewTimer creates a new timer instance
 func NewTimer() *Timer {
+	return NewTimerWithClock(RealClock())
+}
+
+// NewTimerWithClock creates a new timer instance that reads the time from clock
+func NewTimerWithClock(clock Clock) *Timer {
 	return &Timer{
-		status: StatusIdle,
-		mu:     sync.Mutex{},
+		status:      StatusIdle,
+		cycleConfig: DefaultCycleConfig(),
+		mu:          sync.Mutex{},
+		clock:       clock,
 	}
 }
 
@@ -46,22 +70,45 @@ func NewTimerWithPluginManager(pluginManager *plugin.PluginManager) *Timer {
 }
 
 // NewTimerWithManagers creates a new Timer instance with state and history managers.
-func NewTimerWithManagers(stateManager *StateManager, historyManager *HistoryManager) *Timer {
+// A nil clock uses the system clock.
+func NewTimerWithManagers(stateManager *StateManager, historyManager HistoryStore, clock Clock) *Timer {
+	if clock == nil {
+		clock = RealClock()
+	}
 	timer := &Timer{
 		status:         StatusIdle,
+		cycleConfig:    DefaultCycleConfig(),
+		name:           stateManager.name,
 		stateManager:   stateManager,
 		historyManager: historyManager,
+		clock:          clock,
 	}
 
 	// Load existing state if available
 	if state, err := stateManager.LoadState(); err == nil {
 		timer.status = state.Status
+		timer.sessionID = state.SessionID
 		timer.sessionType = state.SessionType
+		timer.annotation = state.Annotation
 		timer.duration = state.Duration
 		timer.startTime = state.StartTime
+		timer.resumedAt = state.ResumedAt
+		timer.pauses = state.Pauses
+		timer.notes = state.Notes
 		timer.elapsed = state.Elapsed
+		timer.cycle = state.Cycle
+		timer.interruptedAt = state.InterruptedAt
+		timer.overtime = state.OvertimeMode
+		timer.planReached = state.PlanReached
+
+		// State written before pauses were tracked has no separate resume time
+		if timer.resumedAt.IsZero() {
+			timer.resumedAt = timer.startTime
+		}
 	}
 
+	timer.claimState()
+
 	return timer
 }
 
@@ -72,18 +119,52 @@ func (t *Timer) Start(duration time.Duration) error {
 
 // StartWithType begins the timer for the specified duration and session type.
 func (t *Timer) StartWithType(duration time.Duration, sessionType SessionType) error {
+	return t.StartAnnotated(duration, sessionType, Annotation{})
+}
+
+// StartAnnotated begins the timer for the specified duration and session type,
+// recording what the session is spent on.
+func (t *Timer) StartAnnotated(duration time.Duration, sessionType SessionType, annotation Annotation) error {
+	return t.StartSession(duration, sessionType, annotation, false)
+}
+
+// StartSession begins the timer like StartAnnotated. With overtime, the
+// session keeps counting past its planned duration until it is stopped.
+func (t *Timer) StartSession(duration time.Duration, sessionType SessionType, annotation Annotation, overtime bool) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
+	return t.startLocked(duration, sessionType, annotation, overtime)
+}
+
+// startLocked starts a new session. Overtime is also enabled when the cycle
+// configuration asks for it. The caller must hold t.mu.
+func (t *Timer) startLocked(duration time.Duration, sessionType SessionType, annotation Annotation, overtime bool) error {
+	if duration <= 0 {
+		return fmt.Errorf("invalid duration")
+	}
+	return t.beginLocked(duration, sessionType, annotation, overtime || t.cycleConfig.Overtime)
+}
+
+// beginLocked starts a new session. A zero duration starts an open-ended
+// stopwatch session. The caller must hold t.mu.
+func (t *Timer) beginLocked(duration time.Duration, sessionType SessionType, annotation Annotation, overtime bool) error {
 	if t.status == StatusRunning {
 		return fmt.Errorf("timer already running")
 	}
-	if duration <= 0 {
-		return fmt.Errorf("invalid duration")
+	if t.status == StatusInterrupted {
+		return fmt.Errorf("an interrupted session must be recovered first")
 	}
+	t.sessionID = newSessionID()
 	t.duration = duration
 	t.sessionType = sessionType
-	t.startTime = time.Now()
+	t.annotation = annotation
+	t.startTime = t.clock.Now()
+	t.resumedAt = t.startTime
+	t.pauses = nil
+	t.notes = nil
 	t.elapsed = 0
+	t.overtime = overtime
+	t.planReached = false
 	t.status = StatusRunning
 
 	// Save state
@@ -97,11 +178,15 @@ func (t *Timer) StartWithType(duration time.Duration, sessionType SessionType) e
 	if t.pluginManager != nil {
 		event := plugin.Event{
 			Type:      plugin.EventTimerStarted,
-			Timestamp: time.Now(),
+			Timestamp: t.clock.Now(),
 			Data: map[string]interface{}{
-				"session_type": string(t.sessionType),
-				"duration":     int(t.duration.Seconds()),
-				"start_time":   t.startTime.Unix(),
+				"session_type":  string(t.sessionType),
+				"timer_name":    t.name,
+				"task":          t.annotation.Task,
+				"tags":          t.annotation.Tags,
+				"duration":      int(t.duration.Seconds()),
+				"start_time":    t.startTime.Unix(),
+				"overtime_mode": t.overtime,
 			},
 		}
 		t.pluginManager.EmitEvent(event)
@@ -114,23 +199,18 @@ func (t *Timer) StartWithType(duration time.Duration, sessionType SessionType) e
 func (t *Timer) Stop() error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
+	t.checkCompletionLocked()
 	if t.status == StatusIdle {
 		return fmt.Errorf("timer not running")
 	}
 
-	// Record session in history if we have a history manager
-	if t.historyManager != nil && t.sessionType != "" {
-		session := SessionRecord{
-			Type:      t.sessionType,
-			Duration:  t.duration,
-			StartTime: t.startTime,
-			EndTime:   time.Now(),
-			Completed: t.status == StatusCompleted,
-		}
-		// Don't check error here as history recording shouldn't prevent stopping
-		if err := t.historyManager.AddSession(session); err != nil {
-			logger.Warn("Failed to add session to history", map[string]interface{}{"error": err.Error()})
-		}
+	// Completed sessions were already recorded when they completed; sessions
+	// in overtime are recorded again with the overtime they ran. Stopping is
+	// how a stopwatch session completes.
+	completed := t.status == StatusCompleted || t.planReached || t.stopwatchLocked()
+	overtime := t.overtimeLocked()
+	if t.status != StatusCompleted {
+		t.recordSessionLocked(completed, false)
 	}
 
 	t.status = StatusIdle
@@ -147,13 +227,17 @@ func (t *Timer) Stop() error {
 	if t.pluginManager != nil {
 		event := plugin.Event{
 			Type:      plugin.EventTimerStopped,
-			Timestamp: time.Now(),
+			Timestamp: t.clock.Now(),
 			Data: map[string]interface{}{
 				"session_type": string(t.sessionType),
+				"timer_name":   t.name,
+				"task":         t.annotation.Task,
+				"tags":         t.annotation.Tags,
 				"duration":     int(t.duration.Seconds()),
 				"start_time":   t.startTime.Unix(),
-				"end_time":     time.Now().Unix(),
-				"completed":    t.status == StatusCompleted,
+				"end_time":     t.clock.Now().Unix(),
+				"completed":    completed,
+				"overtime":     int(overtime.Seconds()),
 			},
 		}
 		t.pluginManager.EmitEvent(event)
@@ -164,14 +248,114 @@ func (t *Timer) Stop() error {
 	return nil
 }
 
+// Skip ends the current session now and starts the next session in the
+// Pomodoro cycle. The skipped session is recorded in history as not completed
+// but still counts as a step in the cycle.
+func (t *Timer) Skip() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.checkCompletionLocked()
+	if t.status != StatusRunning && t.status != StatusPaused {
+		return fmt.Errorf("timer not running")
+	}
+	if t.stopwatchLocked() {
+		return fmt.Errorf("stopwatch sessions are not part of the cycle; stop them instead")
+	}
+
+	// A session in overtime already completed, so it is not counted as skipped
+	t.recordSessionLocked(t.planReached, !t.planReached)
+
+	// Emit timer skipped event for plugins
+	if t.pluginManager != nil {
+		event := plugin.Event{
+			Type:      plugin.EventTimerSkipped,
+			Timestamp: t.clock.Now(),
+			Data: map[string]interface{}{
+				"session_type": string(t.sessionType),
+				"timer_name":   t.name,
+				"task":         t.annotation.Task,
+				"tags":         t.annotation.Tags,
+				"duration":     int(t.duration.Seconds()),
+				"start_time":   t.startTime.Unix(),
+				"end_time":     t.clock.Now().Unix(),
+				"elapsed":      int(t.elapsedLocked().Seconds()),
+			},
+		}
+		t.pluginManager.EmitEvent(event)
+	}
+
+	logger.Info("Timer skipped", map[string]interface{}{"session_type": t.sessionType, "duration": t.duration})
+
+	if !t.planReached {
+		t.cycle.advance(t.sessionType)
+	}
+	t.status = StatusIdle
+	t.elapsed = 0
+
+	next := t.cycle.Next(t.cycleConfig.LongBreakInterval)
+	return t.startLocked(t.cycleConfig.DurationFor(next), next, Annotation{}, false)
+}
+
+// Cancel discards the current session without recording it in history. A
+// session in overtime keeps the record made when it reached its planned end.
+func (t *Timer) Cancel() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.checkCompletionLocked()
+	if t.status != StatusRunning && t.status != StatusPaused {
+		return fmt.Errorf("timer not running")
+	}
+
+	elapsed := t.elapsedLocked()
+	t.status = StatusIdle
+	t.elapsed = 0
+
+	// Save state
+	if t.stateManager != nil {
+		if err := t.stateManager.SaveState(t); err != nil {
+			logger.Warn("Failed to save timer state", map[string]interface{}{"error": err.Error()})
+		}
+	}
+
+	// Emit timer cancelled event for plugins
+	if t.pluginManager != nil {
+		event := plugin.Event{
+			Type:      plugin.EventTimerCancelled,
+			Timestamp: t.clock.Now(),
+			Data: map[string]interface{}{
+				"session_type": string(t.sessionType),
+				"timer_name":   t.name,
+				"task":         t.annotation.Task,
+				"tags":         t.annotation.Tags,
+				"duration":     int(t.duration.Seconds()),
+				"start_time":   t.startTime.Unix(),
+				"elapsed":      int(elapsed.Seconds()),
+			},
+		}
+		t.pluginManager.EmitEvent(event)
+	}
+
+	logger.Info("Timer cancelled", map[string]interface{}{"session_type": t.sessionType, "duration": t.duration})
+
+	return nil
+}
+
 // Pause pauses the timer.
 func (t *Timer) Pause() error {
+	return t.PauseWithReason("")
+}
+
+// PauseWithReason pauses the timer, recording why the session was interrupted.
+func (t *Timer) PauseWithReason(reason string) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
+	t.checkCompletionLocked()
 	if t.status != StatusRunning {
 		return fmt.Errorf("timer not running")
 	}
-	t.elapsed += time.Since(t.startTime)
+	now := t.clock.Now()
+	t.elapsed += now.Sub(t.resumedAt)
+	t.pauses = append(t.pauses, PauseInterval{Start: now, Reason: reason})
 	t.status = StatusPaused
 	// Save state
 	if t.stateManager != nil {
@@ -184,18 +368,22 @@ func (t *Timer) Pause() error {
 	if t.pluginManager != nil {
 		event := plugin.Event{
 			Type:      plugin.EventTimerPaused,
-			Timestamp: time.Now(),
+			Timestamp: t.clock.Now(),
 			Data: map[string]interface{}{
 				"session_type": string(t.sessionType),
+				"timer_name":   t.name,
+				"task":         t.annotation.Task,
+				"tags":         t.annotation.Tags,
 				"duration":     int(t.duration.Seconds()),
 				"start_time":   t.startTime.Unix(),
 				"elapsed":      int(t.elapsed.Seconds()),
+				"reason":       reason,
 			},
 		}
 		t.pluginManager.EmitEvent(event)
 	}
 
-	logger.Info("Timer paused", map[string]interface{}{"session_type": t.sessionType, "duration": t.duration})
+	logger.Info("Timer paused", map[string]interface{}{"session_type": t.sessionType, "duration": t.duration, "reason": reason})
 
 	return nil
 }
@@ -207,7 +395,8 @@ func (t *Timer) Resume() error {
 	if t.status != StatusPaused {
 		return fmt.Errorf("timer not paused")
 	}
-	t.startTime = time.Now()
+	t.resumedAt = t.clock.Now()
+	t.pauses = closePauses(t.pauses, t.resumedAt)
 	t.status = StatusRunning
 	// Save state
 	if t.stateManager != nil {
@@ -220,9 +409,12 @@ func (t *Timer) Resume() error {
 	if t.pluginManager != nil {
 		event := plugin.Event{
 			Type:      plugin.EventTimerResumed,
-			Timestamp: time.Now(),
+			Timestamp: t.clock.Now(),
 			Data: map[string]interface{}{
 				"session_type": string(t.sessionType),
+				"timer_name":   t.name,
+				"task":         t.annotation.Task,
+				"tags":         t.annotation.Tags,
 				"duration":     int(t.duration.Seconds()),
 				"start_time":   t.startTime.Unix(),
 				"elapsed":      int(t.elapsed.Seconds()),
@@ -240,34 +432,8 @@ func (t *Timer) Resume() error {
 func (t *Timer) GetStatus() TimerStatus {
 	t.mu.Lock()
 	defer t.mu.Unlock()
-	// Check if timer has completed
-	if t.status == StatusRunning {
-		elapsed := t.elapsed + time.Since(t.startTime)
-		if elapsed >= t.duration {
-			t.status = StatusCompleted
-			t.elapsed = t.duration
-			// Save state when timer completes
-			if t.stateManager != nil {
-				if err := t.stateManager.SaveState(t); err != nil {
-					logger.Warn("Failed to save timer state", map[string]interface{}{"error": err.Error()})
-				}
-			}
-			// Record session in history immediately when timer completes
-			if t.historyManager != nil && t.sessionType != "" {
-				session := SessionRecord{
-					Type:      t.sessionType,
-					Duration:  t.duration,
-					StartTime: t.startTime,
-					EndTime:   time.Now(),
-					Completed: true,
-				}
-				// Record session immediately
-				if err := t.historyManager.AddSession(session); err != nil {
-					logger.Warn("Failed to add session to history", map[string]interface{}{"error": err.Error()})
-				}
-			}
-		}
-	}
+	t.heartbeatLocked()
+	t.checkCompletionLocked()
 	return t.status
 }
 
@@ -275,27 +441,16 @@ func (t *Timer) GetStatus() TimerStatus {
 func (t *Timer) GetProgress() float64 {
 	t.mu.Lock()
 	defer t.mu.Unlock()
+	t.checkCompletionLocked()
+	return t.progressLocked()
+}
+
+// progressLocked returns the progress of the current session. The caller must hold t.mu.
+func (t *Timer) progressLocked() float64 {
 	if t.duration == 0 {
 		return 0
 	}
-	var elapsed time.Duration
-	if t.status == StatusRunning {
-		elapsed = t.elapsed + time.Since(t.startTime)
-		// Check if timer has completed
-		if elapsed >= t.duration {
-			t.status = StatusCompleted
-			t.elapsed = t.duration
-			// Save state when timer completes
-			if t.stateManager != nil {
-				if err := t.stateManager.SaveState(t); err != nil {
-					logger.Warn("Failed to save timer state", map[string]interface{}{"error": err.Error()})
-				}
-			}
-		}
-	} else {
-		elapsed = t.elapsed
-	}
-	progress := float64(elapsed) / float64(t.duration)
+	progress := float64(t.elapsedLocked()) / float64(t.duration)
 	if progress > 1 {
 		progress = 1
 	}
@@ -313,13 +468,15 @@ func (t *Timer) GetDuration() time.Duration {
 func (t *Timer) GetElapsed() time.Duration {
 	t.mu.Lock()
 	defer t.mu.Unlock()
-	var elapsed time.Duration
+	return t.elapsedLocked()
+}
+
+// elapsedLocked returns the time spent running in the current session. The caller must hold t.mu.
+func (t *Timer) elapsedLocked() time.Duration {
 	if t.status == StatusRunning {
-		elapsed = t.elapsed + time.Since(t.startTime)
-	} else {
-		elapsed = t.elapsed
+		return t.elapsed + t.clock.Now().Sub(t.resumedAt)
 	}
-	return elapsed
+	return t.elapsed
 }
 
 // Reset resets a completed timer to idle state
@@ -330,21 +487,7 @@ func (t *Timer) Reset() error {
 		return fmt.Errorf("can only reset completed timer")
 	}
 
-	// Record session in history if we have a history manager
-	if t.historyManager != nil && t.sessionType != "" {
-		session := SessionRecord{
-			Type:      t.sessionType,
-			Duration:  t.duration,
-			StartTime: t.startTime,
-			EndTime:   time.Now(),
-			Completed: true,
-		}
-		// Don't check error here as history recording shouldn't prevent resetting
-		if err := t.historyManager.AddSession(session); err != nil {
-			logger.Warn("Failed to add session to history", map[string]interface{}{"error": err.Error()})
-		}
-	}
-
+	// The session was already recorded in history when it completed
 	t.status = StatusIdle
 	t.elapsed = 0
 	t.duration = 0
@@ -357,6 +500,11 @@ func (t *Timer) Reset() error {
 	return nil
 }
 
+// Name returns the name of a named timer, or "" for the default timer.
+func (t *Timer) Name() string {
+	return t.name
+}
+
 // GetSessionType returns the session type of the timer
 func (t *Timer) GetSessionType() SessionType {
 	t.mu.Lock()
@@ -364,6 +512,13 @@ func (t *Timer) GetSessionType() SessionType {
 	return t.sessionType
 }
 
+// GetSessionID returns the ID of the current session
+func (t *Timer) GetSessionID() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.sessionID
+}
+
 // GetStartTime returns the start time of the timer
 func (t *Timer) GetStartTime() time.Time {
 	t.mu.Lock()
@@ -371,8 +526,8 @@ func (t *Timer) GetStartTime() time.Time {
 	return t.startTime
 }
 
-// SetHistoryManager sets the history manager for the timer.
-func (t *Timer) SetHistoryManager(historyManager *HistoryManager) {
+// SetHistoryManager sets the history store for the timer.
+func (t *Timer) SetHistoryManager(historyManager HistoryStore) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 	t.historyManager = historyManager
@@ -385,175 +540,38 @@ func (t *Timer) SetPluginManager(pluginManager *plugin.PluginManager) {
 	t.pluginManager = pluginManager
 }
 
-// StartPersistent starts a timer and blocks until completion, with live progress and keypress controls.
-func (t *Timer) StartPersistent(duration time.Duration, sessionType SessionType) error {
+// StartPersistent starts a timer and blocks until completion, with live progress drawn with th and
+// keypress controls as bound in keys. Progress and completion follow the timer's clock. It needs a
+// terminal; sessions that should run without one are started through the daemon.
+func (t *Timer) StartPersistent(duration time.Duration, sessionType SessionType, keys KeyMap, th *theme.Theme) error {
 	if err := t.StartWithType(duration, sessionType); err != nil {
 		return err
 	}
 
 	logger.Info("Timer started", map[string]interface{}{"duration": duration, "session_type": sessionType})
-	fmt.Printf("Timer started for %v\n", duration)
-	fmt.Printf("Session type: %s\n", sessionType)
-	fmt.Println("Press 'p' to pause, 'r' to resume, 'q'/'s' to stop, Ctrl+C to exit.")
-
-	oldState, err := term.MakeRaw(int(os.Stdin.Fd()))
-	if err != nil {
-		return fmt.Errorf("failed to set terminal raw mode: %w", err)
-	}
-	defer term.Restore(int(os.Stdin.Fd()), oldState)
-
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	stopChan := make(chan struct{}, 1)
-	pauseChan := make(chan struct{}, 1)
-	resumeChan := make(chan struct{}, 1)
-	interruptChan := make(chan os.Signal, 1)
-	signal.Notify(interruptChan, os.Interrupt, syscall.SIGTERM)
-
-	// Remove stdinR, only use stdinW for unblocking
-	_, stdinW, _ := os.Pipe()
-	go func() {
-		r := bufio.NewReader(os.Stdin)
-		for {
-			b, err := r.ReadByte()
-			if err != nil {
-				continue
-			}
-			if b == 3 { // Ctrl+C
-				stopChan <- struct{}{}
-				return
-			}
-			switch b {
-			case 'p':
-				pauseChan <- struct{}{}
-			case 'r':
-				resumeChan <- struct{}{}
-			case 'q', 's':
-				stopChan <- struct{}{}
-			}
-		}
-	}()
-
-	// Channel to signal external stop
-	externalStopChan := make(chan struct{}, 1)
-
-	// Goroutine to check for external status changes
-	go func() {
-		ticker := time.NewTicker(500 * time.Millisecond) // Check every 500ms
-		defer ticker.Stop()
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-ticker.C:
-				currentStatus := t.GetStatus()
-				if currentStatus == StatusIdle {
-					externalStopChan <- struct{}{}
-					return
-				}
-			}
-		}
-	}()
+	return RunInteractive(localController{timer: t}, keys, th)
+}
 
-	go func() {
-		<-interruptChan
-		cancel()
-		if err := stdinW.Close(); err != nil {
-			logger.Warn("Failed to close stdin writer", map[string]interface{}{"error": err.Error()})
-		}
-	}()
-
-	startTime := time.Now()
-	endTime := startTime.Add(duration)
-	paused := false
-	var pauseStart time.Time
-	var totalPaused time.Duration
-
-	for {
-		select {
-		case <-ctx.Done():
-			fmt.Print("\r" + strings.Repeat(" ", 120) + "\r")
-			fmt.Println("Timer stopped by user (signal).")
-			if err := t.Stop(); err != nil {
-				logger.Warn("Failed to stop timer", map[string]interface{}{"error": err.Error()})
-			}
-			return nil
-		case <-stopChan:
-			fmt.Print("\r" + strings.Repeat(" ", 120) + "\r")
-			fmt.Println("Timer stopped.")
-			if err := t.Stop(); err != nil {
-				logger.Warn("Failed to stop timer", map[string]interface{}{"error": err.Error()})
-			}
-			return nil
-		case <-externalStopChan:
-			fmt.Print("\r" + strings.Repeat(" ", 120) + "\r")
-			fmt.Println("Timer stopped externally.")
-			return nil
-		case <-pauseChan:
-			if !paused {
-				pauseStart = time.Now()
-				paused = true
-				if err := t.Pause(); err != nil {
-					logger.Warn("Failed to pause timer", map[string]interface{}{"error": err.Error()})
-				}
-				fmt.Print("\r⏸️  PAUSED - Press 'r' to resume" + strings.Repeat(" ", 50))
-			}
-		case <-resumeChan:
-			if paused {
-				if err := t.Resume(); err != nil {
-					logger.Warn("Failed to resume timer", map[string]interface{}{"error": err.Error()})
-				}
-				totalPaused += time.Since(pauseStart)
-				paused = false
-				fmt.Print("\r▶️  RESUMED" + strings.Repeat(" ", 50))
-			}
-		default:
-			if !paused {
-				remaining := endTime.Add(totalPaused).Sub(time.Now())
-				if remaining < 0 {
-					remaining = 0
-				}
-				// Calculate progress
-				elapsed := duration - remaining
-				progress := elapsed.Seconds() / duration.Seconds()
-				if progress > 1 {
-					progress = 1
-				}
-				// Create progress bar
-				progressBar := createProgressBar(progress, 30)
-				percentage := int(progress * 100)
-				// Display timer with progress bar
-				fmt.Printf("\r%s %3d%% %s | %s",
-					progressBar,
-					percentage,
-					formatDuration(remaining),
-					sessionType)
-				logger.Debug("Timer progress", map[string]interface{}{"progress": progress, "remaining": remaining, "elapsed": elapsed})
-				if remaining <= 0 {
-					break
-				}
-			}
-			time.Sleep(200 * time.Millisecond)
-		}
-		if !paused && time.Now().After(endTime.Add(totalPaused)) {
-			break
-		}
+// checkCompletionLocked completes the running session once its duration has
+// elapsed. Overtime sessions keep running instead. The caller must hold t.mu.
+func (t *Timer) checkCompletionLocked() {
+	if t.status != StatusRunning || t.stopwatchLocked() || t.elapsedLocked() < t.duration {
+		return
 	}
-
-	fmt.Print("\r" + strings.Repeat(" ", 120) + "\r")
-	t.handleCompletion()
-	return nil
+	if t.overtime {
+		t.reachPlanLocked()
+		return
+	}
+	t.completeLocked()
 }
 
-// handleCompletion records the session and sends notifications when timer completes.
-func (t *Timer) handleCompletion() {
-	t.mu.Lock()
-	defer t.mu.Unlock()
-
+// completeLocked records the session and notifies plugins when the timer completes.
+// The caller must hold t.mu.
+func (t *Timer) completeLocked() {
 	// Update status to completed
 	t.status = StatusCompleted
 	t.elapsed = t.duration
+	t.cycle.advance(t.sessionType)
 
 	// Save state
 	if t.stateManager != nil {
@@ -563,44 +581,69 @@ func (t *Timer) handleCompletion() {
 	}
 
 	// Record session in history immediately
-	if t.historyManager != nil && t.sessionType != "" {
-		session := SessionRecord{
-			Type:      t.sessionType,
-			Duration:  t.duration,
-			StartTime: t.startTime,
-			EndTime:   time.Now(),
-			Completed: true,
-		}
-		if err := t.historyManager.AddSession(session); err != nil {
-			logger.Warn("Failed to add session to history", map[string]interface{}{"error": err.Error()})
-		}
-	}
+	t.recordSessionLocked(true, false)
 
-	// Emit timer completion event for plugins to handle notifications
+	t.emitCompletedLocked()
+
+	logger.Info("Timer completed", map[string]interface{}{"session_type": t.sessionType, "duration": t.duration})
+
+	t.autoStartNextLocked()
+}
+
+// emitCompletedLocked emits the timer_completed event for plugins to handle
+// notifications. The caller must hold t.mu.
+func (t *Timer) emitCompletedLocked() {
 	if t.pluginManager != nil {
 		logger.Debug("Emitting timer_completed event")
 		event := plugin.Event{
 			Type:      plugin.EventTimerCompleted,
-			Timestamp: time.Now(),
+			Timestamp: t.clock.Now(),
 			Data: map[string]interface{}{
-				"session_type": string(t.sessionType),
-				"duration":     int(t.duration.Seconds()),
-				"start_time":   t.startTime.Unix(),
-				"end_time":     time.Now().Unix(),
-				"completed":    true,
+				"session_type":  string(t.sessionType),
+				"timer_name":    t.name,
+				"task":          t.annotation.Task,
+				"tags":          t.annotation.Tags,
+				"duration":      int(t.duration.Seconds()),
+				"start_time":    t.startTime.Unix(),
+				"end_time":      t.clock.Now().Unix(),
+				"completed":     true,
+				"overtime_mode": t.overtime,
 			},
 		}
+		if message := t.sessionTypes[t.sessionType].Message; message != "" {
+			event.Data["message"] = message
+		}
 		t.pluginManager.EmitEvent(event)
 		logger.Debug("timer_completed event emitted")
-
-		// Give the event processing goroutine time to process the event
-		time.Sleep(100 * time.Millisecond)
 	} else {
 		logger.Debug("No plugin manager available for timer_completed event")
 	}
+}
 
-	logger.Info("Timer completed", map[string]interface{}{"session_type": t.sessionType, "duration": t.duration})
-	fmt.Print("Timer completed! Session recorded.")
+// recordSessionLocked writes the current session to history. Sessions are
+// keyed by ID, so recording the same session again updates its entry instead
+// of adding a duplicate. The caller must hold t.mu.
+func (t *Timer) recordSessionLocked(completed, skipped bool) {
+	if t.historyManager == nil || t.sessionType == "" {
+		return
+	}
+
+	endTime := t.clock.Now()
+	session := SessionRecord{
+		ID:         t.sessionID,
+		TimerName:  t.name,
+		Type:       t.sessionType,
+		Annotation: t.annotation,
+		Duration:   t.duration,
+		StartTime:  t.startTime,
+		EndTime:    endTime,
+		Completed:  completed,
+		Skipped:    skipped,
+		Pauses:     closePauses(t.pauses, endTime),
+		Notes:      t.notes,
+		Overtime:   t.overtimeLocked(),
+	}
+	t.addSessionLocked(session)
 }
 
 // sendNotification sends a system notification based on session type.
@@ -633,30 +676,6 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%d minute%s %d second%s", minutes, plural(minutes), seconds, plural(seconds))
 }
 
-// createProgressBar creates a visual progress bar
-func createProgressBar(progress float64, width int) string {
-	if progress < 0 {
-		progress = 0
-	}
-	if progress > 1 {
-		progress = 1
-	}
-
-	filled := int(float64(width) * progress)
-	empty := width - filled
-
-	bar := "["
-	for i := 0; i < filled; i++ {
-		bar += "█"
-	}
-	for i := 0; i < empty; i++ {
-		bar += "░"
-	}
-	bar += "]"
-
-	return bar
-}
-
 func plural(n int) string {
 	if n == 1 {
 		return ""
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/rsmacapinlac/pomodux/internal/logger"
+	"github.com/rsmacapinlac/pomodux/internal/theme"
 )
 
 // TestMain initializes the logger for all tests in this package
@@ -246,7 +247,7 @@ func TestStartPersistent(t *testing.T) {
 		// Start persistent timer in a goroutine
 		errChan := make(chan error, 1)
 		go func() {
-			errChan <- timer.StartPersistent(duration, sessionType)
+			errChan <- timer.StartPersistent(duration, sessionType, nil, theme.Default())
 		}()
 
 		// Wait for timer to complete
@@ -275,7 +276,7 @@ func TestStartPersistent(t *testing.T) {
 		// Start persistent timer in a goroutine
 		errChan := make(chan error, 1)
 		go func() {
-			errChan <- timer.StartPersistent(duration, sessionType)
+			errChan <- timer.StartPersistent(duration, sessionType, nil, theme.Default())
 		}()
 
 		// Wait for timer to complete
@@ -301,7 +302,7 @@ func TestStartPersistent(t *testing.T) {
 		duration := 0 * time.Millisecond
 		sessionType := SessionTypeWork
 
-		err := timer.StartPersistent(duration, sessionType)
+		err := timer.StartPersistent(duration, sessionType, nil, theme.Default())
 		if err == nil {
 			t.Error("StartPersistent should return error for zero duration")
 		}
@@ -319,55 +320,13 @@ func TestStartPersistent(t *testing.T) {
 		}
 
 		// Try to start persistent timer while already running
-		err = timer.StartPersistent(duration, sessionType)
+		err = timer.StartPersistent(duration, sessionType, nil, theme.Default())
 		if err == nil {
 			t.Error("StartPersistent should return error when timer already running")
 		}
 	})
 }
 
-func TestProgressBarGeneration(t *testing.T) {
-	t.Run("ProgressBarZero", func(t *testing.T) {
-		bar := createProgressBar(0.0, 10)
-		expected := "[░░░░░░░░░░]"
-		if bar != expected {
-			t.Errorf("expected progress bar %s, got %s", expected, bar)
-		}
-	})
-
-	t.Run("ProgressBarHalf", func(t *testing.T) {
-		bar := createProgressBar(0.5, 10)
-		expected := "[█████░░░░░]"
-		if bar != expected {
-			t.Errorf("expected progress bar %s, got %s", expected, bar)
-		}
-	})
-
-	t.Run("ProgressBarFull", func(t *testing.T) {
-		bar := createProgressBar(1.0, 10)
-		expected := "[██████████]"
-		if bar != expected {
-			t.Errorf("expected progress bar %s, got %s", expected, bar)
-		}
-	})
-
-	t.Run("ProgressBarNegative", func(t *testing.T) {
-		bar := createProgressBar(-0.5, 10)
-		expected := "[░░░░░░░░░░]"
-		if bar != expected {
-			t.Errorf("expected progress bar %s, got %s", expected, bar)
-		}
-	})
-
-	t.Run("ProgressBarOverflow", func(t *testing.T) {
-		bar := createProgressBar(1.5, 10)
-		expected := "[██████████]"
-		if bar != expected {
-			t.Errorf("expected progress bar %s, got %s", expected, bar)
-		}
-	})
-}
-
 func TestDurationFormatting(t *testing.T) {
 	t.Run("FormatSeconds", func(t *testing.T) {
 		duration := 45 * time.Second
@@ -0,0 +1,66 @@
+package timer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// untilLayouts are the accepted forms of a wall-clock time of day.
+var untilLayouts = []string{"15:04", "3:04pm", "3pm"}
+
+// untilDateLayout is the accepted form of an explicit date before a time of day.
+const untilDateLayout = "2006-01-02"
+
+// ParseUntil returns the wall-clock moment described by value, in now's
+// location. Accepted forms are a time of day ("14:30", "2:30pm"), optionally
+// preceded by "today", "tomorrow" or a date ("2025-01-06 14:30").
+//
+// A bare time of day that has already passed today means that time tomorrow.
+// A target on an explicit day that has already passed is an error.
+func ParseUntil(value string, now time.Time) (time.Time, error) {
+	fields := strings.Fields(strings.ToLower(value))
+	if len(fields) == 0 || len(fields) > 2 {
+		return time.Time{}, fmt.Errorf("invalid time %q (expected e.g. 14:30 or \"tomorrow 09:00\")", value)
+	}
+
+	day := now
+	explicitDay := len(fields) == 2
+	if explicitDay {
+		switch fields[0] {
+		case "today":
+		case "tomorrow":
+			day = now.AddDate(0, 0, 1)
+		default:
+			date, err := time.ParseInLocation(untilDateLayout, fields[0], now.Location())
+			if err != nil {
+				return time.Time{}, fmt.Errorf("invalid day %q (expected today, tomorrow or YYYY-MM-DD)", fields[0])
+			}
+			day = date
+		}
+	}
+
+	clock, err := parseTimeOfDay(fields[len(fields)-1])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: %w", value, err)
+	}
+
+	target := time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, now.Location())
+	if !target.After(now) {
+		if explicitDay {
+			return time.Time{}, fmt.Errorf("%s is in the past", target.Format("2006-01-02 15:04"))
+		}
+		target = time.Date(day.Year(), day.Month(), day.Day()+1, clock.Hour(), clock.Minute(), 0, 0, now.Location())
+	}
+	return target, nil
+}
+
+// parseTimeOfDay parses a time of day in one of the untilLayouts.
+func parseTimeOfDay(value string) (time.Time, error) {
+	for _, layout := range untilLayouts {
+		if clock, err := time.Parse(layout, value); err == nil {
+			return clock, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("expected a time of day such as 14:30 or 2:30pm")
+}
@@ -0,0 +1,84 @@
+package timer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseUntil(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	now := time.Date(2025, 1, 6, 10, 15, 30, 0, loc)
+
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"14:30", time.Date(2025, 1, 6, 14, 30, 0, 0, loc)},
+		{"2:30pm", time.Date(2025, 1, 6, 14, 30, 0, 0, loc)},
+		{"3PM", time.Date(2025, 1, 6, 15, 0, 0, 0, loc)},
+		// A time of day that has already passed means tomorrow
+		{"09:00", time.Date(2025, 1, 7, 9, 0, 0, 0, loc)},
+		{"10:15", time.Date(2025, 1, 7, 10, 15, 0, 0, loc)},
+		{"today 18:00", time.Date(2025, 1, 6, 18, 0, 0, 0, loc)},
+		{"tomorrow 09:00", time.Date(2025, 1, 7, 9, 0, 0, 0, loc)},
+		{"2025-01-08 08:45", time.Date(2025, 1, 8, 8, 45, 0, 0, loc)},
+	}
+	for _, tt := range tests {
+		got, err := ParseUntil(tt.value, now)
+		if err != nil {
+			t.Errorf("ParseUntil(%q) failed: %v", tt.value, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseUntil(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseUntilRejectsInvalidAndPastTargets(t *testing.T) {
+	now := time.Date(2025, 1, 6, 10, 15, 0, 0, time.UTC)
+
+	for _, value := range []string{"", "soon", "25:00", "next week 09:00", "today 09:00", "2025-01-05 12:00"} {
+		if _, err := ParseUntil(value, now); err == nil {
+			t.Errorf("expected ParseUntil(%q) to fail", value)
+		}
+	}
+}
+
+func TestParseUntilAcrossDSTChange(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data not available: %v", err)
+	}
+	// Clocks go forward at 02:00 on 2025-03-09, so the night is an hour shorter
+	now := time.Date(2025, 3, 8, 22, 0, 0, 0, loc)
+
+	got, err := ParseUntil("tomorrow 06:00", now)
+	if err != nil {
+		t.Fatalf("ParseUntil failed: %v", err)
+	}
+	if d := got.Sub(now); d != 7*time.Hour {
+		t.Errorf("expected 7h until 06:00 across the DST change, got %v", d)
+	}
+}
+
+func TestSnapshotEndTime(t *testing.T) {
+	start := testStart
+	clock := NewFakeClock(start)
+	timer := NewTimerWithClock(clock)
+
+	if err := timer.Start(25 * time.Minute); err != nil {
+		t.Fatalf("failed to start timer: %v", err)
+	}
+	clock.Advance(5 * time.Minute)
+	if end := timer.Snapshot().EndTime; !end.Equal(start.Add(25 * time.Minute)) {
+		t.Errorf("expected the session to end at 09:25, got %v", end)
+	}
+
+	if err := timer.Pause(); err != nil {
+		t.Fatalf("failed to pause timer: %v", err)
+	}
+	if end := timer.Snapshot().EndTime; !end.IsZero() {
+		t.Errorf("expected no end time while paused, got %v", end)
+	}
+}
@@ -0,0 +1,200 @@
+// Package tui implements the full-screen terminal interface, started with
+// `pomodux tui`. It draws with plain ANSI escape sequences and drives the
+// timer through the same Controller as the single-line interactive display.
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/rsmacapinlac/pomodux/internal/logger"
+	"github.com/rsmacapinlac/pomodux/internal/theme"
+	"github.com/rsmacapinlac/pomodux/internal/timer"
+)
+
+// Controller is the set of timer operations the TUI drives.
+type Controller interface {
+	timer.Controller
+	// StartNext starts the next session in the Pomodoro cycle
+	StartNext() error
+}
+
+// historyRefresh is how often today's sessions are re-read when nothing else changed
+const historyRefresh = 30 * time.Second
+
+// Terminal control sequences
+const (
+	enterScreen = "\x1b[?1049h\x1b[?25l"
+	leaveScreen = "\x1b[?25h\x1b[?1049l"
+	home        = "\x1b[H"
+	clearToEnd  = "\x1b[K"
+)
+
+// Run shows the full-screen interface, drawn with the glyphs of th, until the
+// user quits. Sessions of the user-defined types that count as work add to
+// the time focused today. Quitting leaves the session running.
+func Run(ctrl Controller, history timer.HistoryStore, keys timer.KeyMap, types timer.SessionTypes, th *theme.Theme) error {
+	in := int(os.Stdin.Fd())
+	out := int(os.Stdout.Fd())
+	if !term.IsTerminal(in) || !term.IsTerminal(out) {
+		return fmt.Errorf("the TUI needs an interactive terminal")
+	}
+
+	oldState, err := term.MakeRaw(in)
+	if err != nil {
+		return fmt.Errorf("failed to set terminal raw mode: %w", err)
+	}
+	defer term.Restore(in, oldState)
+
+	fmt.Print(enterScreen)
+	defer fmt.Print(leaveScreen)
+
+	keyChan := make(chan byte, 16)
+	stopKeys := timer.ReadKeys(os.Stdin, keyChan)
+	defer stopKeys()
+
+	interruptChan := make(chan os.Signal, 1)
+	signal.Notify(interruptChan, syscall.SIGTERM)
+	defer signal.Stop(interruptChan)
+
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+
+	v := view{keys: keys, sessionTypes: types, theme: th}
+	var loadedAt time.Time
+	var loadedFor string
+
+	for {
+		select {
+		case <-interruptChan:
+			return nil
+		case key := <-keyChan:
+			if quit := handleKey(&v, key, ctrl); quit {
+				return nil
+			}
+		case <-ticker.C:
+		}
+
+		v.now = time.Now()
+		snapshot, err := ctrl.Snapshot()
+		if err != nil {
+			v.message = fmt.Sprintf("Failed to get timer status: %v", err)
+		} else {
+			v.snapshot = snapshot
+		}
+
+		// Today's sessions change when a session starts or ends
+		current := string(v.snapshot.Status) + v.snapshot.SessionID
+		if current != loadedFor || v.now.Sub(loadedAt) >= historyRefresh {
+			v.today = todaySessions(history, v.now)
+			loadedFor = current
+			loadedAt = v.now
+		}
+
+		// The size is read on every frame so that resizes are redrawn
+		width, height, err := term.GetSize(out)
+		if err != nil {
+			width, height = 80, 24
+		}
+		draw(v.render(width, height))
+	}
+}
+
+// handleKey applies a keypress to the view and timer. It returns true when
+// the user quits.
+func handleKey(v *view, key byte, ctrl Controller) bool {
+	if key == 3 { // Ctrl+C
+		return true
+	}
+
+	if v.noting {
+		switch key {
+		case '\r', '\n':
+			v.noting = false
+			if err := ctrl.AddNote(v.note); err != nil {
+				v.message = fmt.Sprintf("Failed to add note: %v", err)
+			} else {
+				v.message = "Note added."
+			}
+		case 27: // Escape
+			v.noting = false
+		case 127, 8: // Backspace
+			if runes := []rune(v.note); len(runes) > 0 {
+				v.note = string(runes[:len(runes)-1])
+			}
+		default:
+			if key >= ' ' {
+				v.note += string([]byte{key})
+			}
+		}
+		return false
+	}
+
+	v.message = ""
+	var err error
+	switch v.keys[key] {
+	case timer.KeyStart:
+		err = ctrl.StartNext()
+	case timer.KeyStop:
+		err = ctrl.Stop()
+	case timer.KeyPause:
+		err = ctrl.Pause()
+	case timer.KeyResume:
+		err = ctrl.Resume()
+	case timer.KeySkip:
+		err = ctrl.Skip()
+	case timer.KeyExtend:
+		err = ctrl.Extend(timer.ExtendStep)
+	case timer.KeyNote:
+		v.noting = true
+		v.note = ""
+	case timer.KeyToggleDisplay:
+		v.compact = !v.compact
+	}
+	if err != nil {
+		v.message = err.Error()
+		logger.Debug("TUI action failed", map[string]interface{}{"action": v.keys[key], "error": err.Error()})
+	}
+	return false
+}
+
+// todaySessions returns the sessions that started today, most recent first.
+func todaySessions(history timer.HistoryStore, now time.Time) []timer.SessionRecord {
+	if history == nil {
+		return nil
+	}
+	sessions, err := history.GetAllSessions()
+	if err != nil {
+		logger.Warn("Failed to load session history", map[string]interface{}{"error": err.Error()})
+		return nil
+	}
+
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	var today []timer.SessionRecord
+	for _, session := range sessions {
+		if !session.StartTime.Before(midnight) {
+			today = append(today, session)
+		}
+	}
+	return today
+}
+
+// draw writes a frame over the previous one.
+func draw(lines []string) {
+	var frame strings.Builder
+	frame.WriteString(home)
+	for i, line := range lines {
+		frame.WriteString(line)
+		frame.WriteString(clearToEnd)
+		if i < len(lines)-1 {
+			frame.WriteString("\r\n")
+		}
+	}
+	fmt.Print(frame.String())
+}
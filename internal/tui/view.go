@@ -0,0 +1,267 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rsmacapinlac/pomodux/internal/theme"
+	"github.com/rsmacapinlac/pomodux/internal/timer"
+)
+
+// view is everything drawn on one frame of the TUI.
+type view struct {
+	snapshot timer.Snapshot
+	// today holds the sessions recorded today, most recent first
+	today []timer.SessionRecord
+	keys  timer.KeyMap
+	// sessionTypes tells which user-defined session types count as focus
+	sessionTypes timer.SessionTypes
+	// theme supplies the progress bar glyphs and completion marks; its colors
+	// are not used, as escape sequences would upset the layout
+	theme *theme.Theme
+	now   time.Time
+	// compact hides today's sessions
+	compact bool
+	// noting is set while a note is being typed
+	noting  bool
+	note    string
+	message string
+}
+
+// render lays the view out as exactly height lines of at most width characters.
+func (v view) render(width, height int) []string {
+	top := []string{
+		spread(" Pomodux", v.now.Format("Mon 2 Jan 15:04")+" ", width),
+		strings.Repeat("─", width),
+		"",
+	}
+	top = append(top, v.sessionLines(width)...)
+	top = append(top, "", v.cycleLine())
+
+	var history []string
+	if !v.compact {
+		history = append([]string{""}, v.todayLines()...)
+	}
+
+	bottom := []string{strings.Repeat("─", width), v.footerLine()}
+	if v.message != "" {
+		bottom = append(bottom, " "+v.message)
+	}
+
+	// Today's sessions give way first when the terminal is short
+	room := height - len(top) - len(bottom)
+	if room < 0 {
+		top = top[:len(top)+room]
+		room = 0
+	}
+	if len(history) > room {
+		history = history[:room]
+	}
+
+	lines := append(top, history...)
+	for len(lines) < height-len(bottom) {
+		lines = append(lines, "")
+	}
+	lines = append(lines, bottom...)
+	if len(lines) > height {
+		lines = lines[len(lines)-height:]
+	}
+
+	for i, line := range lines {
+		lines[i] = truncate(line, width)
+	}
+	return lines
+}
+
+// sessionLines describes the current session.
+func (v view) sessionLines(width int) []string {
+	s := v.snapshot
+	switch s.Status {
+	case timer.StatusIdle, timer.StatusCompleted:
+		lines := []string{"  No session running"}
+		if key, ok := v.keys.KeyFor(timer.KeyStart); ok {
+			lines = append(lines, fmt.Sprintf("  Press '%c' to start the next session (%s)", key, s.NextSessionType))
+		}
+		return lines
+	case timer.StatusInterrupted:
+		return []string{
+			fmt.Sprintf("  %s session interrupted by a crash or reboot", s.SessionType),
+			"  Run 'pomodux recover' to record, resume or discard it",
+		}
+	}
+
+	lines := []string{fmt.Sprintf("  %s · %s", strings.ToUpper(string(s.SessionType)), s.Status)}
+	if s.Task != "" {
+		lines = append(lines, "  Task: "+s.Task)
+	}
+	if len(s.Tags) > 0 {
+		lines = append(lines, "  Tags: "+strings.Join(s.Tags, ", "))
+	}
+	lines = append(lines, "")
+
+	switch {
+	case s.Stopwatch:
+		lines = append(lines, fmt.Sprintf("  %s elapsed", clock(s.Elapsed)))
+	case s.Overtime > 0 || (s.OvertimeMode && s.Elapsed >= s.Duration):
+		lines = append(lines, fmt.Sprintf("  +%s overtime (planned %s)", clock(s.Overtime), clock(s.Duration)))
+	default:
+		lines = append(lines, fmt.Sprintf("  %s remaining of %s", clock(s.Remaining), clock(s.Duration)))
+	}
+
+	if !s.Stopwatch {
+		barWidth := width - 12
+		if barWidth > 50 {
+			barWidth = 50
+		}
+		if barWidth > 0 {
+			lines = append(lines, fmt.Sprintf("  %s %3d%%", v.progressBar(s.Progress, barWidth), int(s.Progress*100)))
+		}
+	}
+
+	switch {
+	case s.Status == timer.StatusPaused:
+		paused := "  Paused"
+		if n := len(s.Pauses); n > 0 && s.Pauses[n-1].Reason != "" {
+			paused += ": " + s.Pauses[n-1].Reason
+		}
+		lines = append(lines, paused)
+	case !s.EndTime.IsZero():
+		lines = append(lines, "  Ends at "+s.EndTime.Format("15:04"))
+	}
+	return lines
+}
+
+// cycleLine shows the position in the Pomodoro cycle.
+func (v view) cycleLine() string {
+	s := v.snapshot
+	var dots strings.Builder
+	for i := 0; i < s.LongBreakInterval; i++ {
+		if i < s.Pomodoros {
+			dots.WriteString("●")
+		} else {
+			dots.WriteString("○")
+		}
+	}
+	return fmt.Sprintf("  Cycle  %s  %d/%d pomodoros · next: %s", dots.String(), s.Pomodoros, s.LongBreakInterval, s.NextSessionType)
+}
+
+// todayLines lists the sessions recorded today.
+func (v view) todayLines() []string {
+	var focused time.Duration
+	for _, session := range v.today {
+		if v.sessionTypes.CountsAsWork(session.Type) {
+			focused += session.ActiveDuration()
+		}
+	}
+
+	lines := []string{fmt.Sprintf("  Today  %d session%s · %s focused", len(v.today), plural(len(v.today)), clock(focused))}
+	for _, session := range v.today {
+		mark := v.mark(theme.IconIncomplete, "✗")
+		if session.Completed {
+			mark = v.mark(theme.IconCompleted, "✓")
+		}
+		line := fmt.Sprintf("   %s  %-10s %8s  %s", session.StartTime.Format("15:04"), session.Type, clock(session.ActiveDuration()), mark)
+		if session.Task != "" {
+			line += "  " + session.Task
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// footerLine shows the key hints, or the note being typed.
+func (v view) footerLine() string {
+	if v.noting {
+		return " Note: " + v.note + "█  (Enter to save, Esc to cancel)"
+	}
+
+	hints := []struct {
+		action timer.KeyAction
+		text   string
+	}{
+		{timer.KeyStart, "start"},
+		{timer.KeyPause, "pause"},
+		{timer.KeyResume, "resume"},
+		{timer.KeyStop, "stop"},
+		{timer.KeySkip, "skip"},
+		{timer.KeyExtend, fmt.Sprintf("+%dm", int(timer.ExtendStep.Minutes()))},
+		{timer.KeyNote, "note"},
+		{timer.KeyToggleDisplay, "history"},
+	}
+
+	var parts []string
+	for _, hint := range hints {
+		if key, ok := v.keys.KeyFor(hint.action); ok {
+			parts = append(parts, fmt.Sprintf("%c %s", key, hint.text))
+		}
+	}
+	parts = append(parts, "^C quit")
+	return " " + strings.Join(parts, "  ")
+}
+
+// clock formats a duration as m:ss, or h:mm:ss from an hour up.
+func clock(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	d = d.Round(time.Second)
+	hours := int(d / time.Hour)
+	minutes := int(d/time.Minute) % 60
+	seconds := int(d/time.Second) % 60
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%02d:%02d", minutes, seconds)
+}
+
+// progressBar draws progress as a bar width characters wide, with the theme's glyphs.
+func (v view) progressBar(progress float64, width int) string {
+	if progress < 0 {
+		progress = 0
+	}
+	if progress > 1 {
+		progress = 1
+	}
+	bar := theme.Default().Bar
+	if v.theme != nil {
+		bar = v.theme.Bar
+	}
+	filled := int(float64(width) * progress)
+	return bar.Left + strings.Repeat(bar.Filled, filled) + strings.Repeat(bar.Empty, width-filled) + bar.Right
+}
+
+// mark returns the theme's icon for a completed or incomplete session, or
+// fallback if the theme has none.
+func (v view) mark(icon, fallback string) string {
+	if v.theme != nil && v.theme.Icons[icon] != "" {
+		return v.theme.Icons[icon]
+	}
+	return fallback
+}
+
+// spread places left and right at either end of a line width characters wide.
+func spread(left, right string, width int) string {
+	gap := width - utf8.RuneCountInString(left) - utf8.RuneCountInString(right)
+	if gap < 1 {
+		return left
+	}
+	return left + strings.Repeat(" ", gap) + right
+}
+
+// truncate cuts s to at most width characters.
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:width])
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
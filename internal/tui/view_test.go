@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rsmacapinlac/pomodux/internal/theme"
+	"github.com/rsmacapinlac/pomodux/internal/timer"
+)
+
+func testView() view {
+	now := time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC)
+	return view{
+		snapshot: timer.Snapshot{
+			Status:            timer.StatusRunning,
+			SessionType:       timer.SessionTypeWork,
+			Annotation:        timer.Annotation{Task: "Review PR 42"},
+			Duration:          25 * time.Minute,
+			Elapsed:           10 * time.Minute,
+			Remaining:         15 * time.Minute,
+			Progress:          0.4,
+			EndTime:           now.Add(15 * time.Minute),
+			Pomodoros:         2,
+			LongBreakInterval: 4,
+			NextSessionType:   timer.SessionTypeBreak,
+		},
+		today: []timer.SessionRecord{
+			{Type: timer.SessionTypeWork, StartTime: now.Add(-time.Hour), EndTime: now.Add(-35 * time.Minute), Completed: true, Annotation: timer.Annotation{Task: "Write docs"}},
+		},
+		keys: timer.NewKeyMap(map[string]string{"pause": "x", "stop": "q", "start": "s"}),
+		now:  now,
+	}
+}
+
+func TestRenderShowsSessionCycleAndToday(t *testing.T) {
+	screen := strings.Join(testView().render(80, 24), "\n")
+
+	for _, want := range []string{
+		"WORK · running",
+		"Task: Review PR 42",
+		"15:00 remaining of 25:00",
+		"Ends at 10:15",
+		"●●○○  2/4 pomodoros · next: break",
+		"Today  1 session · 25:00 focused",
+		"09:00  work",
+		"Write docs",
+		"x pause",
+		"q stop",
+	} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("expected screen to contain %q, got:\n%s", want, screen)
+		}
+	}
+	if strings.Contains(screen, "resume") {
+		t.Errorf("expected unbound actions to be left out of the hints")
+	}
+}
+
+func TestRenderFitsTerminal(t *testing.T) {
+	v := testView()
+	for _, size := range []struct{ width, height int }{{80, 24}, {40, 10}, {20, 4}} {
+		lines := v.render(size.width, size.height)
+		if len(lines) != size.height {
+			t.Errorf("expected %d lines at %dx%d, got %d", size.height, size.width, size.height, len(lines))
+		}
+		for _, line := range lines {
+			if n := utf8.RuneCountInString(line); n > size.width {
+				t.Errorf("line %q is %d wide at width %d", line, n, size.width)
+			}
+		}
+		// The key hints stay visible however small the terminal is
+		if !strings.Contains(lines[len(lines)-1], "x pause") {
+			t.Errorf("expected key hints on the last line at %dx%d, got %q", size.width, size.height, lines[len(lines)-1])
+		}
+	}
+}
+
+func TestRenderCompactHidesToday(t *testing.T) {
+	v := testView()
+	v.compact = true
+	if screen := strings.Join(v.render(80, 24), "\n"); strings.Contains(screen, "Today") {
+		t.Errorf("expected the compact view to hide today's sessions")
+	}
+}
+
+func TestRenderIdleOffersToStartNext(t *testing.T) {
+	v := testView()
+	v.snapshot.Status = timer.StatusIdle
+	screen := strings.Join(v.render(80, 24), "\n")
+	if !strings.Contains(screen, "Press 's' to start the next session (break)") {
+		t.Errorf("expected the idle view to offer the next session, got:\n%s", screen)
+	}
+}
+
+func TestRenderUsesThemeGlyphs(t *testing.T) {
+	v := testView()
+	th, _ := theme.Builtin("ascii")
+	v.theme = th
+	screen := strings.Join(v.render(80, 24), "\n")
+
+	if !strings.Contains(screen, "[####") || !strings.Contains(screen, "-----]") {
+		t.Errorf("expected an ascii progress bar, got:\n%s", screen)
+	}
+	if strings.Contains(screen, "✓") || !strings.Contains(screen, "+") {
+		t.Errorf("expected the ascii completion mark, got:\n%s", screen)
+	}
+}
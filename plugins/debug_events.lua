@@ -26,6 +26,24 @@ pomodux.register_hook("timer_stopped", function(event)
     print("🔴 DEBUG: timer_stopped - " .. session_type .. " session stopped after " .. duration .. " seconds")
 end)
 
+pomodux.register_hook("timer_skipped", function(event)
+    local session_type = event.data.session_type
+    local elapsed = event.data.elapsed
+    print("⏭️  DEBUG: timer_skipped - " .. session_type .. " session skipped after " .. elapsed .. " seconds")
+end)
+
+pomodux.register_hook("timer_cancelled", function(event)
+    local session_type = event.data.session_type
+    local elapsed = event.data.elapsed
+    print("❌ DEBUG: timer_cancelled - " .. session_type .. " session cancelled after " .. elapsed .. " seconds")
+end)
+
+pomodux.register_hook("timer_recovered", function(event)
+    local session_type = event.data.session_type
+    local action = event.data.action
+    print("🩹 DEBUG: timer_recovered - interrupted " .. session_type .. " session recovered with action " .. action)
+end)
+
 pomodux.register_hook("timer_paused", function(event)
     local session_type = event.data.session_type
     local elapsed = event.data.elapsed
@@ -31,9 +31,13 @@ pomodux.register_hook("timer_completed", function(event)
         title = "Long Break Complete"
         message = "Long break finished. Time to get back to work!"
     else
-        title = "Timer Complete"
+        title = session_type .. " Session Complete"
         message = "Your timer session has finished."
     end
+    -- Session types defined in the configuration can bring their own text
+    if event.data.message then
+        message = event.data.message
+    end
     print("LUA DEBUG: Sending completion notification: " .. title .. " - " .. message)
     pomodux.log("DEBUG: Sending completion notification: " .. title .. " - " .. message)
     send_notification(title, message)